@@ -0,0 +1,368 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Banded is a matrix whose nonzero elements all lie within LowerBandwidth diagonals below the main diagonal and UpperBandwidth diagonals above it.
+type Banded struct {
+	Rows, Columns                  int
+	LowerBandwidth, UpperBandwidth int
+	Values                         []float64
+}
+
+// BandedLU is the LU decomposition with partial pivoting of a banded matrix, which takes O(n*bandwidth) memory.
+type BandedLU struct {
+	n, lower, upper int
+	lu              []float64
+	pivot           []int
+}
+
+// BandedCholesky is the Cholesky decomposition A = L*L^T of a symmetric positive definite banded matrix, where L has the same lower bandwidth as A.
+type BandedCholesky struct {
+	n, bandwidth int
+	l            []float64
+}
+
+// NewBanded will return a zero matrix of the given size and bandwidths.
+func NewBanded(rows, columns, lower, upper int) (*Banded, error) {
+	if rows < 1 || columns < 1 {
+		return nil, errors.New("Incorrect matrix dimensions")
+	}
+	if lower < 0 || upper < 0 {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+	return &Banded{
+		Rows:           rows,
+		Columns:        columns,
+		LowerBandwidth: lower,
+		UpperBandwidth: upper,
+		Values:         make([]float64, rows*(lower+upper+1)),
+	}, nil
+}
+
+// Bandwidth will return the number of diagonals below and above the main diagonal that contain nonzero elements.
+func (m MatrixStruct) Bandwidth() (lower, upper int) {
+	for i := 0; i < m.Rows; i++ {
+		for j := 0; j < m.Columns; j++ {
+			if m.Elements[i*m.Columns+j] == 0 {
+				continue
+			}
+			if i-j > lower {
+				lower = i - j
+			}
+			if j-i > upper {
+				upper = j - i
+			}
+		}
+	}
+	return lower, upper
+}
+
+// Banded will return the matrix in banded storage, using the narrowest band that holds all of its nonzero elements.
+func (m MatrixStruct) Banded() *Banded {
+	lower, upper := m.Bandwidth()
+	b, _ := NewBanded(m.Rows, m.Columns, lower, upper)
+	for i := 0; i < m.Rows; i++ {
+		start, end := b.columnRange(i)
+		for j := start; j < end; j++ {
+			b.Values[b.index(i, j)] = m.Elements[i*m.Columns+j]
+		}
+	}
+	return b
+}
+
+// index returns the position in Values of the element in row i and column j, which must be inside the band.
+func (b *Banded) index(i, j int) int {
+	return i*(b.LowerBandwidth+b.UpperBandwidth+1) + j - i + b.LowerBandwidth
+}
+
+// columnRange returns the first column of row i inside the band and one past the last.
+func (b *Banded) columnRange(i int) (start, end int) {
+	start, end = i-b.LowerBandwidth, i+b.UpperBandwidth+1
+	if start < 0 {
+		start = 0
+	}
+	if end > b.Columns {
+		end = b.Columns
+	}
+	return start, end
+}
+
+// inBand reports whether the element in row i and column j is inside the band.
+func (b *Banded) inBand(i, j int) bool {
+	return j-i <= b.UpperBandwidth && i-j <= b.LowerBandwidth
+}
+
+// Dims will return the number of rows and columns.
+func (b *Banded) Dims() (rows, columns int) {
+	return b.Rows, b.Columns
+}
+
+// GetValue will return the element in row y and column x.
+func (b *Banded) GetValue(y, x int) (float64, error) {
+	if y < 0 || y >= b.Rows || x < 0 || x >= b.Columns {
+		return 0, fmt.Errorf("The indexes must be in the bounds of the matrix.\nMatrix is %dx%d, indexes are %d,%d", b.Rows, b.Columns, y, x)
+	}
+
+	if !b.inBand(y, x) {
+		return 0, nil
+	}
+	return b.Values[b.index(y, x)], nil
+}
+
+// SetValue will set the element in row y and column x, which must be inside the band.
+func (b *Banded) SetValue(y, x int, value float64) error {
+	if y < 0 || y >= b.Rows || x < 0 || x >= b.Columns {
+		return fmt.Errorf("The indexes must be in the bounds of the matrix.\nMatrix is %dx%d, indexes are %d,%d", b.Rows, b.Columns, y, x)
+	}
+
+	if !b.inBand(y, x) {
+		return errors.New("Element is outside the band of the matrix")
+	}
+	b.Values[b.index(y, x)] = value
+	return nil
+}
+
+// MulVec will write the product of the matrix and x into dst.
+func (b *Banded) MulVec(dst, x []float64) {
+	for i := 0; i < b.Rows; i++ {
+		sum := float64(0)
+		start, end := b.columnRange(i)
+		for j := start; j < end; j++ {
+			sum += b.Values[b.index(i, j)] * x[j]
+		}
+		dst[i] = sum
+	}
+}
+
+// T will return the transpose of the matrix, which satisfies Interface.
+func (b *Banded) T() Interface {
+	return b.Transpose()
+}
+
+// Transpose will return the transpose of the matrix, which swaps the lower and upper bandwidths.
+func (b *Banded) Transpose() *Banded {
+	t, _ := NewBanded(b.Columns, b.Rows, b.UpperBandwidth, b.LowerBandwidth)
+	for i := 0; i < b.Rows; i++ {
+		start, end := b.columnRange(i)
+		for j := start; j < end; j++ {
+			t.Values[t.index(j, i)] = b.Values[b.index(i, j)]
+		}
+	}
+	return t
+}
+
+// Dense will return the matrix as a MatrixStruct.
+func (b *Banded) Dense() *MatrixStruct {
+	m, _ := Zeros(b.Rows, b.Columns)
+	for i := 0; i < b.Rows; i++ {
+		start, end := b.columnRange(i)
+		for j := start; j < end; j++ {
+			m.Elements[i*b.Columns+j] = b.Values[b.index(i, j)]
+		}
+	}
+	return m
+}
+
+// CSR will return the nonzero elements of the matrix in compressed sparse row format.
+func (b *Banded) CSR() *CSR {
+	a := &CSR{Rows: b.Rows, Columns: b.Columns, RowPointers: make([]int, b.Rows+1)}
+	for i := 0; i < b.Rows; i++ {
+		start, end := b.columnRange(i)
+		for j := start; j < end; j++ {
+			if value := b.Values[b.index(i, j)]; value != 0 {
+				a.ColumnIndices = append(a.ColumnIndices, j)
+				a.Values = append(a.Values, value)
+			}
+		}
+		a.RowPointers[i+1] = len(a.Values)
+	}
+	return a
+}
+
+// IsSymmetric will report whether the matrix is square and equal to its transpose.
+func (b *Banded) IsSymmetric() bool {
+	if b.Rows != b.Columns {
+		return false
+	}
+
+	max := float64(0)
+	for _, value := range b.Values {
+		max = math.Max(max, math.Abs(value))
+	}
+	// The tolerance is proportional to the size of the largest element.
+	tol := float64(b.Rows) * epsilon * max
+	for i := 0; i < b.Rows; i++ {
+		start, end := b.columnRange(i)
+		for j := start; j < end; j++ {
+			other, _ := b.GetValue(j, i)
+			if math.Abs(b.Values[b.index(i, j)]-other) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// LU will return the LU decomposition of a square banded matrix with partial pivoting, in O(n*lower*(lower+upper)) time.
+func (b *Banded) LU() (*BandedLU, error) {
+	if b.Rows != b.Columns {
+		return nil, errors.New("Not a square matrix")
+	}
+
+	// The factors are stored by row like a Banded matrix with lower diagonals below and lower+upper above, leaving room for the fill from row interchanges.
+	n, kl, ku := b.Rows, b.LowerBandwidth, b.UpperBandwidth
+	f, _ := NewBanded(n, n, kl, kl+ku)
+	for i := 0; i < n; i++ {
+		start, end := b.columnRange(i)
+		for j := start; j < end; j++ {
+			f.Values[f.index(i, j)] = b.Values[b.index(i, j)]
+		}
+	}
+
+	pivot := make([]int, n)
+	for k := 0; k < n; k++ {
+		last, end := k+kl, k+kl+ku
+		if last > n-1 {
+			last = n - 1
+		}
+		if end > n-1 {
+			end = n - 1
+		}
+
+		p := k
+		for i := k + 1; i <= last; i++ {
+			if math.Abs(f.Values[f.index(i, k)]) > math.Abs(f.Values[f.index(p, k)]) {
+				p = i
+			}
+		}
+		pivot[k] = p
+		if f.Values[f.index(p, k)] == 0 {
+			return nil, errors.New("Matrix is singular")
+		}
+		if p != k {
+			for j := k; j <= end; j++ {
+				f.Values[f.index(k, j)], f.Values[f.index(p, j)] = f.Values[f.index(p, j)], f.Values[f.index(k, j)]
+			}
+		}
+
+		d := f.Values[f.index(k, k)]
+		for i := k + 1; i <= last; i++ {
+			l := f.Values[f.index(i, k)] / d
+			f.Values[f.index(i, k)] = l
+			for j := k + 1; j <= end; j++ {
+				f.Values[f.index(i, j)] -= l * f.Values[f.index(k, j)]
+			}
+		}
+	}
+
+	return &BandedLU{n: n, lower: kl, upper: kl + ku, lu: f.Values, pivot: pivot}, nil
+}
+
+// index returns the position in the factors of the element in row i and column j.
+func (f *BandedLU) index(i, j int) int {
+	return i*(f.lower+f.upper+1) + j - i + f.lower
+}
+
+// Solve will return the solution of A*x = b using the factors.
+func (f *BandedLU) Solve(b []float64) ([]float64, error) {
+	n := f.n
+	if len(b) != n {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	x := append([]float64(nil), b...)
+	for k := 0; k < n; k++ {
+		if p := f.pivot[k]; p != k {
+			x[k], x[p] = x[p], x[k]
+		}
+		for i := k + 1; i <= k+f.lower && i < n; i++ {
+			x[i] -= f.lu[f.index(i, k)] * x[k]
+		}
+	}
+	for k := n - 1; k >= 0; k-- {
+		for j := k + 1; j <= k+f.upper && j < n; j++ {
+			x[k] -= f.lu[f.index(k, j)] * x[j]
+		}
+		x[k] /= f.lu[f.index(k, k)]
+	}
+	return x, nil
+}
+
+// Cholesky will return the Cholesky decomposition of a symmetric positive definite banded matrix in O(n*bandwidth^2) time.
+func (b *Banded) Cholesky() (*BandedCholesky, error) {
+	if b.Rows != b.Columns {
+		return nil, errors.New("Not a square matrix")
+	}
+	if !b.IsSymmetric() {
+		return nil, errors.New("Not a symmetric matrix")
+	}
+
+	// Only the lower band is read.
+	n, k := b.Rows, b.LowerBandwidth
+	f := &BandedCholesky{n: n, bandwidth: k, l: make([]float64, n*(k+1))}
+	for j := 0; j < n; j++ {
+		d := b.Values[b.index(j, j)]
+		for p := bandStart(j, k); p < j; p++ {
+			d -= f.l[f.index(j, p)] * f.l[f.index(j, p)]
+		}
+		if d <= 0 || math.IsNaN(d) {
+			return nil, errors.New("Matrix is not positive definite")
+		}
+		d = math.Sqrt(d)
+		f.l[f.index(j, j)] = d
+
+		for i := j + 1; i <= j+k && i < n; i++ {
+			sum := b.Values[b.index(i, j)]
+			for p := bandStart(i, k); p < j; p++ {
+				sum -= f.l[f.index(i, p)] * f.l[f.index(j, p)]
+			}
+			f.l[f.index(i, j)] = sum / d
+		}
+	}
+	return f, nil
+}
+
+// bandStart returns the first column of row i inside a lower band of k diagonals, which is max(0, i-k).
+func bandStart(i, k int) int {
+	if i < k {
+		return 0
+	}
+	return i - k
+}
+
+// index returns the position in the factor of the element in row i and column j of L.
+func (f *BandedCholesky) index(i, j int) int {
+	return i*(f.bandwidth+1) + j - i + f.bandwidth
+}
+
+// Factor will return the lower triangular factor L as a banded matrix.
+func (f *BandedCholesky) Factor() *Banded {
+	return &Banded{Rows: f.n, Columns: f.n, LowerBandwidth: f.bandwidth, Values: append([]float64(nil), f.l...)}
+}
+
+// Solve will return the solution of A*x = b using the factor, with a forward and a backward substitution.
+func (f *BandedCholesky) Solve(b []float64) ([]float64, error) {
+	n, k := f.n, f.bandwidth
+	if len(b) != n {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	x := append([]float64(nil), b...)
+	for i := 0; i < n; i++ {
+		for p := bandStart(i, k); p < i; p++ {
+			x[i] -= f.l[f.index(i, p)] * x[p]
+		}
+		x[i] /= f.l[f.index(i, i)]
+	}
+	for i := n - 1; i >= 0; i-- {
+		for p := i + 1; p <= i+k && p < n; p++ {
+			x[i] -= f.l[f.index(p, i)] * x[p]
+		}
+		x[i] /= f.l[f.index(i, i)]
+	}
+	return x, nil
+}
@@ -0,0 +1,123 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// bandedExample returns a nonsymmetric matrix with two diagonals below the main diagonal and one above, with a zero on the diagonal so that solving it needs pivoting.
+func bandedExample() *MatrixStruct {
+	m, _ := Matrix(5, 5, []float64{
+		0, 1, 0, 0, 0,
+		2, 3, 4, 0, 0,
+		5, 6, 7, 8, 0,
+		0, 9, 1, 2, 3,
+		0, 0, 4, 5, 6,
+	})
+	return m
+}
+
+func TestBanded(t *testing.T) {
+	assert := assert.New(t)
+
+	m := bandedExample()
+	lower, upper := m.Bandwidth()
+	assert.Equal(2, lower)
+	assert.Equal(1, upper)
+
+	B := m.Banded()
+	assert.Equal(5*4, len(B.Values))
+	assert.Equal(m.Elements, B.Dense().Elements)
+	assert.Equal(m.Transpose().Elements, B.Transpose().Dense().Elements)
+
+	value, _ := B.GetValue(3, 1)
+	assert.Equal(9.0, value)
+	value, _ = B.GetValue(0, 4)
+	assert.Equal(0.0, value)
+	_, err := B.GetValue(5, 0)
+	assert.NotNil(err)
+	assert.EqualError(B.SetValue(0, 3, 1), "Element is outside the band of the matrix")
+
+	x := []float64{1, -2, 3, -4, 5}
+	dst := make([]float64, 5)
+	B.MulVec(dst, x)
+	expected, _ := m.Multiply(&MatrixStruct{Rows: 5, Columns: 1, Capacity: 5, Elements: x})
+	assert.InDeltaSlice(expected.Elements, dst, 1e-12)
+
+	rectangular, _ := Matrix(2, 3, []float64{1, 2, 0, 0, 3, 4})
+	assert.Equal(rectangular.Elements, rectangular.Banded().Dense().Elements)
+
+	_, err = NewBanded(0, 3, 0, 0)
+	assert.EqualError(err, "Incorrect matrix dimensions")
+	_, err = NewBanded(3, 3, -1, 0)
+	assert.EqualError(err, "matrix dimensions do not agree")
+}
+
+func TestBandedLU(t *testing.T) {
+	assert := assert.New(t)
+
+	m := bandedExample()
+	f, err := m.Banded().LU()
+	assert.Nil(err)
+	b := []float64{1, 2, 3, 4, 5}
+	x, err := f.Solve(b)
+	assert.Nil(err)
+	expected, _ := m.Solve(&MatrixStruct{Rows: 5, Columns: 1, Capacity: 5, Elements: b})
+	assert.InDeltaSlice(expected.Elements, x, 1e-12)
+
+	// A wider system checks the fill from row interchanges at the edges of the band.
+	A := convectionDiffusion(7)
+	f, err = A.Banded().LU()
+	assert.Nil(err)
+	b = sequence(A.Rows)
+	x, _ = f.Solve(b)
+	expected, _ = A.Solve(&MatrixStruct{Rows: len(b), Columns: 1, Capacity: len(b), Elements: b})
+	assert.InDeltaSlice(expected.Elements, x, 1e-10)
+
+	singular, _ := Matrix(3, 3, []float64{1, 2, 0, 2, 4, 0, 0, 0, 1})
+	_, err = singular.Banded().LU()
+	assert.EqualError(err, "Matrix is singular")
+	_, err = f.Solve(b[:3])
+	assert.EqualError(err, "matrix dimensions do not agree")
+}
+
+func TestBandedCholesky(t *testing.T) {
+	assert := assert.New(t)
+
+	A := poisson(7)
+	B := A.Banded()
+	assert.Equal(7, B.LowerBandwidth)
+	f, err := B.Cholesky()
+	assert.Nil(err)
+
+	L := f.Factor().Dense()
+	product, _ := L.Multiply(L.Transpose())
+	assert.InDeltaSlice(A.Elements, product.Elements, 1e-12)
+
+	b := sequence(A.Rows)
+	x, err := f.Solve(b)
+	assert.Nil(err)
+	expected, _ := A.Solve(&MatrixStruct{Rows: len(b), Columns: 1, Capacity: len(b), Elements: b})
+	assert.InDeltaSlice(expected.Elements, x, 1e-10)
+
+	_, err = A.ScalarMultiply(-1).Banded().Cholesky()
+	assert.EqualError(err, "Matrix is not positive definite")
+	_, err = bandedExample().Banded().Cholesky()
+	assert.EqualError(err, "Not a symmetric matrix")
+}
+
+func BenchmarkBandedLU(b *testing.B) {
+	B := convectionDiffusion(30).Banded()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		B.LU()
+	}
+}
+
+func BenchmarkBandedCholesky(b *testing.B) {
+	B := poisson(30).Banded()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		B.Cholesky()
+	}
+}
@@ -0,0 +1,161 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// Cholesky will return the lower triangular matrix L that satisfies m = L*L^T. The matrix must be symmetric and positive definite, otherwise an error is returned.
+func (m MatrixStruct) Cholesky() (*MatrixStruct, error) {
+	if !m.IsSymmetric() {
+		return nil, errors.New("Not a symmetric matrix")
+	}
+
+	n := m.Rows
+	L, _ := Zeros(n, n)
+	l := L.Elements
+
+	for j := 0; j < n; j++ {
+		d := m.Elements[j*n+j]
+		for k := 0; k < j; k++ {
+			d -= l[j*n+k] * l[j*n+k]
+		}
+		if d <= 0 || math.IsNaN(d) {
+			return nil, errors.New("Matrix is not positive definite")
+		}
+		d = math.Sqrt(d)
+		l[j*n+j] = d
+
+		for i := j + 1; i < n; i++ {
+			sum := m.Elements[i*n+j]
+			for k := 0; k < j; k++ {
+				sum -= l[i*n+k] * l[j*n+k]
+			}
+			l[i*n+j] = sum / d
+		}
+	}
+
+	return L, nil
+}
+
+// CholeskySolve will return the matrix x that satisfies m*x = b using the Cholesky decomposition of the selected matrix.
+func (m MatrixStruct) CholeskySolve(b *MatrixStruct) (*MatrixStruct, error) {
+	if b.Rows != m.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	L, err := m.Cholesky()
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := L.TriangleSolve(b)
+	if err != nil {
+		return nil, err
+	}
+	return L.Transpose().TriangleSolve(y)
+}
+
+// CholeskyInverse will compute the inverse of a symmetric positive definite matrix as L^-T * L^-1 where L is the Cholesky factor of the selected matrix.
+func (m MatrixStruct) CholeskyInverse() (*MatrixStruct, error) {
+	L, err := m.Cholesky()
+	if err != nil {
+		return nil, err
+	}
+
+	LInv, err := L.TriangleInverse()
+	if err != nil {
+		return nil, err
+	}
+	return LInv.Transpose().Multiply(LInv)
+}
+
+// LDL will return the unit lower triangular matrix L and diagonal matrix D that satisfy m = L*D*L^T for a positive semi-definite matrix.
+func (m MatrixStruct) LDL() (L, D *MatrixStruct, err error) {
+	if !m.IsSymmetric() {
+		return nil, nil, errors.New("Not a symmetric matrix")
+	}
+
+	n := m.Rows
+	L, _ = Eye(n, n)
+	D, _ = Zeros(n, n)
+	l := L.Elements
+	d := make([]float64, n)
+	tol := float64(n) * epsilon * m.maxAbs()
+
+	for j := 0; j < n; j++ {
+		dj := m.Elements[j*n+j]
+		for k := 0; k < j; k++ {
+			dj -= l[j*n+k] * l[j*n+k] * d[k]
+		}
+		if dj < -tol {
+			return nil, nil, errors.New("Matrix is not positive semi-definite")
+		}
+
+		for i := j + 1; i < n; i++ {
+			sum := m.Elements[i*n+j]
+			for k := 0; k < j; k++ {
+				sum -= l[i*n+k] * l[j*n+k] * d[k]
+			}
+
+			// A zero pivot is allowed as long as the rest of its column is also zero.
+			if dj <= tol {
+				if math.Abs(sum) > tol {
+					return nil, nil, errors.New("Matrix is not positive semi-definite")
+				}
+				continue
+			}
+			l[i*n+j] = sum / dj
+		}
+
+		if dj <= tol {
+			dj = 0
+		}
+		d[j] = dj
+		D.Elements[j*n+j] = dj
+	}
+
+	return L, D, nil
+}
+
+// CholeskyUpdate will return the Cholesky factor of L*L^T + x*x^T in O(n^2) work, given the lower triangular factor L and a vector x.
+func CholeskyUpdate(L, x *MatrixStruct) (*MatrixStruct, error) {
+	return choleskyRankOne(L, x, 1)
+}
+
+// CholeskyDowndate will return the Cholesky factor of L*L^T - x*x^T, or an error if the result is not positive definite.
+func CholeskyDowndate(L, x *MatrixStruct) (*MatrixStruct, error) {
+	return choleskyRankOne(L, x, -1)
+}
+
+// choleskyRankOne applies a sequence of rotations, hyperbolic ones when sign is negative, to fold x into the factor one column at a time.
+func choleskyRankOne(L, x *MatrixStruct, sign float64) (*MatrixStruct, error) {
+	n := L.Rows
+	if !L.IsSquare() || len(x.Elements) != n {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	L1 := L.Clone()
+	l := L1.Elements
+	w := make([]float64, n)
+	copy(w, x.Elements)
+
+	for k := 0; k < n; k++ {
+		lkk := l[k*n+k]
+		r2 := lkk*lkk + sign*w[k]*w[k]
+		if r2 <= 0 || lkk == 0 {
+			return nil, errors.New("Matrix is not positive definite")
+		}
+		r := math.Sqrt(r2)
+		c := r / lkk
+		s := w[k] / lkk
+		l[k*n+k] = r
+
+		for i := k + 1; i < n; i++ {
+			l[i*n+k] = (l[i*n+k] + sign*s*w[i]) / c
+			w[i] = c*w[i] - s*l[i*n+k]
+		}
+	}
+
+	return L1, nil
+}
@@ -0,0 +1,148 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98})
+	assert.Nil(err)
+
+	L, err := a.Cholesky()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{2, 0, 0, 6, 1, 0, -8, 5, 3}, L.Elements, 1e-12)
+
+	b, err := Matrix(2, 2, []float64{1, 2, 2, 1})
+	assert.Nil(err)
+	c, err := b.Cholesky()
+	assert.Nil(c)
+	assert.NotNil(err)
+
+	d, err := Matrix(2, 2, []float64{1, 2, 3, 4})
+	assert.Nil(err)
+	e, err := d.Cholesky()
+	assert.Nil(e)
+	assert.NotNil(err)
+}
+
+func BenchmarkCholesky(b *testing.B) {
+	a, _ := Matrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98})
+	for n := 0; n < b.N; n++ {
+		_, _ = a.Cholesky()
+	}
+}
+
+func TestCholeskySolve(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98})
+	assert.Nil(err)
+	b, err := Vector(-40, -111, 223)
+	assert.Nil(err)
+
+	x, err := a.CholeskySolve(b)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1, -1, 2}, x.Elements, 1e-9)
+
+	c, err := Vector(1, 2)
+	assert.Nil(err)
+	y, err := a.CholeskySolve(c)
+	assert.Nil(y)
+	assert.NotNil(err)
+}
+
+func TestCholeskyInverse(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98})
+	assert.Nil(err)
+
+	inv, err := a.CholeskyInverse()
+	assert.Nil(err)
+
+	I, err := inv.Multiply(a)
+	assert.Nil(err)
+	eye, _ := Eye(3, 3)
+	assert.InDeltaSlice(eye.Elements, I.Elements, 1e-9)
+}
+
+func TestLDL(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98})
+	assert.Nil(err)
+
+	L, D, err := a.LDL()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1, 0, 0, 3, 1, 0, -4, 5, 1}, L.Elements, 1e-12)
+	assert.InDeltaSlice([]float64{4, 0, 0, 0, 1, 0, 0, 0, 9}, D.Elements, 1e-12)
+
+	b, err := Matrix(3, 3, []float64{1, 1, 0, 1, 1, 0, 0, 0, 2})
+	assert.Nil(err)
+
+	L, D, err = b.LDL()
+	assert.Nil(err)
+	LD, _ := L.Multiply(D)
+	B, _ := LD.Multiply(L.Transpose())
+	assert.InDeltaSlice(b.Elements, B.Elements, 1e-12)
+
+	c, err := Matrix(2, 2, []float64{0, 1, 1, 0})
+	assert.Nil(err)
+	_, _, err = c.LDL()
+	assert.NotNil(err)
+}
+
+func BenchmarkLDL(b *testing.B) {
+	a, _ := Matrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = a.LDL()
+	}
+}
+
+func TestCholeskyUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98})
+	assert.Nil(err)
+	L, err := a.Cholesky()
+	assert.Nil(err)
+
+	x, err := Vector(1, 2, 3)
+	assert.Nil(err)
+
+	L1, err := CholeskyUpdate(L, x)
+	assert.Nil(err)
+	assert.True(L1.IsLowerTriangular())
+
+	xx, _ := x.Multiply(x.Transpose())
+	b, _ := a.Add(xx)
+	expected, err := b.Cholesky()
+	assert.Nil(err)
+	assert.InDeltaSlice(expected.Elements, L1.Elements, 1e-12)
+
+	L2, err := CholeskyDowndate(L1, x)
+	assert.Nil(err)
+	assert.InDeltaSlice(L.Elements, L2.Elements, 1e-12)
+
+	y, err := Vector(10, 0, 0)
+	assert.Nil(err)
+	_, err = CholeskyDowndate(L, y)
+	assert.NotNil(err)
+
+	z, err := Vector(1, 2)
+	assert.Nil(err)
+	_, err = CholeskyUpdate(L, z)
+	assert.NotNil(err)
+}
+
+func BenchmarkCholeskyUpdate(b *testing.B) {
+	a, _ := Matrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98})
+	L, _ := a.Cholesky()
+	x, _ := Vector(1, 2, 3)
+	for n := 0; n < b.N; n++ {
+		_, _ = CholeskyUpdate(L, x)
+	}
+}
@@ -0,0 +1,142 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// condEstimateIterations limits the number of power iterations used by the 1-norm estimator, Higham found that five is nearly always enough.
+const condEstimateIterations = 5
+
+// Cond will return the condition number of the matrix in the selected norm, which is infinite for a singular matrix.
+func (m MatrixStruct) Cond(normType NormType) (float64, error) {
+	// The 2-norm condition number comes exactly from the singular values and is defined for any shape.
+	if normType == Norm2 {
+		s, err := m.SingularValues()
+		if err != nil {
+			return 0, err
+		}
+		if s[len(s)-1] == 0 {
+			return math.Inf(1), nil
+		}
+		return s[0] / s[len(s)-1], nil
+	}
+
+	if !m.IsSquare() {
+		return 0, errors.New("Not a square matrix")
+	}
+
+	norm, err := m.Normal(normType)
+	if err != nil {
+		return 0, err
+	}
+
+	f := m.luDecompose()
+	if f.singular {
+		return math.Inf(1), nil
+	}
+
+	eye, _ := Eye(m.Rows, m.Rows)
+	inv, err := f.solve(eye)
+	if err != nil {
+		return math.Inf(1), nil
+	}
+
+	invNorm, err := inv.Normal(normType)
+	if err != nil {
+		return 0, err
+	}
+	return norm * invNorm, nil
+}
+
+// CondEstimate will return an estimate of the 1-norm condition number of a square matrix from its LU factors, which is much cheaper than Cond.
+func (m MatrixStruct) CondEstimate() (float64, error) {
+	if !m.IsSquare() {
+		return 0, errors.New("Not a square matrix")
+	}
+
+	f := m.luDecompose()
+	if f.singular {
+		return math.Inf(1), nil
+	}
+
+	// Hager's method with Higham's refinements is usually within a factor of three of the true value.
+	invNorm, err := estimateInverseNorm1(m.Rows, f.solve, f.solveTranspose)
+	if err != nil {
+		return math.Inf(1), nil
+	}
+	return m.norm1() * invNorm, nil
+}
+
+// isIllConditioned reports whether a condition number estimate means that solutions are not accurate to even one digit at working precision.
+func isIllConditioned(cond float64) bool {
+	return math.IsNaN(cond) || cond*epsilon >= 1
+}
+
+// estimateInverseNorm1 estimates ||A^-1||_1 for an n by n matrix A given functions that solve A*x = b and A^T*x = b.
+func estimateInverseNorm1(n int, solve, solveTranspose func(b *MatrixStruct) (*MatrixStruct, error)) (float64, error) {
+	x, _ := Zeros(n, 1)
+	for i := range x.Elements {
+		x.Elements[i] = 1 / float64(n)
+	}
+
+	estimate := float64(0)
+	last := -1
+	for iter := 0; iter < condEstimateIterations; iter++ {
+		y, err := solve(x)
+		if err != nil {
+			return 0, err
+		}
+		estimate = y.norm1()
+
+		xi := y.Clone()
+		for i, value := range xi.Elements {
+			if value >= 0 {
+				xi.Elements[i] = 1
+			} else {
+				xi.Elements[i] = -1
+			}
+		}
+
+		z, err := solveTranspose(xi)
+		if err != nil {
+			return 0, err
+		}
+
+		j := 0
+		zx := float64(0)
+		for i, value := range z.Elements {
+			if math.Abs(value) > math.Abs(z.Elements[j]) {
+				j = i
+			}
+			zx += value * x.Elements[i]
+		}
+		if iter > 0 && (math.Abs(z.Elements[j]) <= zx || j == last) {
+			break
+		}
+
+		for i := range x.Elements {
+			x.Elements[i] = 0
+		}
+		x.Elements[j] = 1
+		last = j
+	}
+
+	// Higham's alternative vector guards against the cases where the power iteration gets stuck.
+	for i := range x.Elements {
+		x.Elements[i] = 1
+		if n > 1 {
+			x.Elements[i] += float64(i) / float64(n-1)
+		}
+		if i%2 == 1 {
+			x.Elements[i] = -x.Elements[i]
+		}
+	}
+	y, err := solve(x)
+	if err != nil {
+		return 0, err
+	}
+	alternative := 2 * y.norm1() / float64(3*n)
+
+	return math.Max(estimate, alternative), nil
+}
@@ -0,0 +1,94 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCond(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(2, 2, []float64{1, 2, 3, 4})
+	assert.Nil(err)
+
+	cond, err := a.Cond(Norm1)
+	assert.Nil(err)
+	assert.InDelta(21, cond, 1e-12)
+
+	cond, err = a.Cond(NormInf)
+	assert.Nil(err)
+	assert.InDelta(21, cond, 1e-12)
+
+	cond, err = a.Cond(Norm2)
+	assert.Nil(err)
+	assert.InDelta(14.933034373659268, cond, 1e-12)
+
+	b, err := Matrix(2, 2, []float64{1, 2, 2, 4})
+	assert.Nil(err)
+	cond, err = b.Cond(Norm1)
+	assert.Nil(err)
+	assert.True(math.IsInf(cond, 1))
+
+	c, err := Matrix(3, 2, []float64{1, 0, 0, 2, 0, 0})
+	assert.Nil(err)
+	cond, err = c.Cond(Norm2)
+	assert.Nil(err)
+	assert.InDelta(2, cond, 1e-12)
+
+	_, err = c.Cond(Norm1)
+	assert.NotNil(err)
+
+	_, err = a.Cond(NormType(42))
+	assert.NotNil(err)
+
+	d, err := Matrix(2, 2, []float64{1, math.NaN(), 3, 4})
+	assert.Nil(err)
+	_, err = d.Cond(Norm2)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+}
+
+func TestCondEstimate(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	assert.Nil(err)
+
+	exact, err := a.Cond(Norm1)
+	assert.Nil(err)
+	estimate, err := a.CondEstimate()
+	assert.Nil(err)
+	assert.True(estimate <= exact*(1+1e-12))
+	assert.True(estimate >= exact/3)
+
+	h, err := Zeros(8, 8)
+	assert.Nil(err)
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			h.Elements[i*8+j] = 1 / float64(i+j+1)
+		}
+	}
+	exact, err = h.Cond(Norm1)
+	assert.Nil(err)
+	estimate, err = h.CondEstimate()
+	assert.Nil(err)
+	assert.InEpsilon(exact, estimate, 0.5)
+
+	b, err := Matrix(2, 2, []float64{1, 2, 2, 4})
+	assert.Nil(err)
+	estimate, err = b.CondEstimate()
+	assert.Nil(err)
+	assert.True(math.IsInf(estimate, 1))
+
+	c, err := Matrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	_, err = c.CondEstimate()
+	assert.NotNil(err)
+}
+
+func BenchmarkCondEstimate(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	for n := 0; n < b.N; n++ {
+		_, _ = a.CondEstimate()
+	}
+}
@@ -0,0 +1,570 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// Eigen will return the eigenvalues of a square matrix, which may be complex when the matrix is not symmetric.
+func (m MatrixStruct) Eigen() ([]complex128, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+
+	H, V := m.hessenberg()
+	d, e, err := schurIterate(H, V)
+	if err != nil {
+		return nil, err
+	}
+
+	return eigenvalues(d, e), nil
+}
+
+// EigenVectors will return the eigenvalues of a square matrix along with the unit length right eigenvectors, so that m*vectors[i] = values[i]*vectors[i].
+func (m MatrixStruct) EigenVectors() (values []complex128, vectors [][]complex128, err error) {
+	if !m.IsSquare() {
+		return nil, nil, errors.New("Not a square matrix")
+	}
+
+	H, V := m.hessenberg()
+	d, e, err := schurIterate(H, V)
+	if err != nil {
+		return nil, nil, err
+	}
+	schurVectors(H, V, d, e)
+
+	n := m.Rows
+	values = eigenvalues(d, e)
+	vectors = make([][]complex128, n)
+	for j := 0; j < n; j++ {
+		vector := make([]complex128, n)
+		// A complex pair is stored as its real and imaginary parts in consecutive columns of V.
+		for i := 0; i < n; i++ {
+			switch {
+			case e[j] == 0:
+				vector[i] = complex(V.Elements[i*n+j], 0)
+			case e[j] > 0:
+				vector[i] = complex(V.Elements[i*n+j], V.Elements[i*n+j+1])
+			default:
+				vector[i] = complex(V.Elements[i*n+j-1], -V.Elements[i*n+j])
+			}
+		}
+
+		norm := float64(0)
+		for _, value := range vector {
+			norm = math.Hypot(norm, math.Hypot(real(value), imag(value)))
+		}
+		if norm != 0 {
+			for i := range vector {
+				vector[i] /= complex(norm, 0)
+			}
+		}
+		vectors[j] = vector
+	}
+
+	return values, vectors, nil
+}
+
+func eigenvalues(d, e []float64) []complex128 {
+	// Complex conjugate pairs sit next to each other with the positive imaginary part first.
+	values := make([]complex128, len(d))
+	for i := range d {
+		values[i] = complex(d[i], e[i])
+	}
+	return values
+}
+
+// hessenberg reduces a square matrix to upper Hessenberg form H = Q^T*A*Q using Householder reflections, returning H and the orthogonal matrix Q.
+func (m MatrixStruct) hessenberg() (H, Q *MatrixStruct) {
+	n := m.Rows
+	H = m.Clone()
+	h := H.Elements
+	Q, _ = Eye(n, n)
+	v := Q.Elements
+	ort := make([]float64, n)
+	high := n - 1
+
+	for k := 1; k <= high-1; k++ {
+		scale := float64(0)
+		for i := k; i <= high; i++ {
+			scale += math.Abs(h[i*n+k-1])
+		}
+		if scale == 0 {
+			continue
+		}
+
+		sum := float64(0)
+		for i := high; i >= k; i-- {
+			ort[i] = h[i*n+k-1] / scale
+			sum += ort[i] * ort[i]
+		}
+		g := math.Sqrt(sum)
+		if ort[k] > 0 {
+			g = -g
+		}
+		sum -= ort[k] * g
+		ort[k] -= g
+
+		for j := k; j < n; j++ {
+			f := float64(0)
+			for i := high; i >= k; i-- {
+				f += ort[i] * h[i*n+j]
+			}
+			f /= sum
+			for i := k; i <= high; i++ {
+				h[i*n+j] -= f * ort[i]
+			}
+		}
+
+		for i := 0; i <= high; i++ {
+			f := float64(0)
+			for j := high; j >= k; j-- {
+				f += ort[j] * h[i*n+j]
+			}
+			f /= sum
+			for j := k; j <= high; j++ {
+				h[i*n+j] -= f * ort[j]
+			}
+		}
+		ort[k] *= scale
+		h[k*n+k-1] = scale * g
+	}
+
+	// Accumulate the reflections, the Householder vectors are still stored below the subdiagonal of H.
+	for k := high - 1; k >= 1; k-- {
+		if h[k*n+k-1] == 0 {
+			continue
+		}
+		for i := k + 1; i <= high; i++ {
+			ort[i] = h[i*n+k-1]
+		}
+		for j := k; j <= high; j++ {
+			g := float64(0)
+			for i := k; i <= high; i++ {
+				g += ort[i] * v[i*n+j]
+			}
+			// Double division avoids possible underflow.
+			g = (g / ort[k]) / h[k*n+k-1]
+			for i := k; i <= high; i++ {
+				v[i*n+j] += g * ort[i]
+			}
+		}
+	}
+
+	for i := 2; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			h[i*n+j] = 0
+		}
+	}
+
+	return
+}
+
+// schurIterate reduces the upper Hessenberg matrix H to real Schur form with the Francis double shift QR algorithm, accumulating the transformations into V.
+func schurIterate(H, V *MatrixStruct) (d, e []float64, err error) {
+	nn := H.Rows
+	h := H.Elements
+	v := V.Elements
+	// d and e hold the real and imaginary parts of the eigenvalues.
+	d = make([]float64, nn)
+	e = make([]float64, nn)
+
+	n := nn - 1
+	exshift := float64(0)
+	var p, q, r, s, z, w, x, y float64
+
+	norm := float64(0)
+	for i := 0; i < nn; i++ {
+		for j := int(math.Max(float64(i-1), 0)); j < nn; j++ {
+			norm += math.Abs(h[i*nn+j])
+		}
+	}
+
+	iter := 0
+	total := 0
+	for n >= 0 {
+		// Look for a single small subdiagonal element.
+		l := n
+		for l > 0 {
+			s = math.Abs(h[(l-1)*nn+l-1]) + math.Abs(h[l*nn+l])
+			if s == 0 {
+				s = norm
+			}
+			if math.Abs(h[l*nn+l-1]) < epsilon*s {
+				break
+			}
+			l--
+		}
+
+		if l == n {
+			// One root found.
+			h[n*nn+n] += exshift
+			d[n] = h[n*nn+n]
+			e[n] = 0
+			if n > 0 {
+				h[n*nn+n-1] = 0
+			}
+			n--
+			iter = 0
+		} else if l == n-1 {
+			// Two roots found.
+			w = h[n*nn+n-1] * h[(n-1)*nn+n]
+			p = (h[(n-1)*nn+n-1] - h[n*nn+n]) / 2
+			q = p*p + w
+			z = math.Sqrt(math.Abs(q))
+			h[n*nn+n] += exshift
+			h[(n-1)*nn+n-1] += exshift
+			x = h[n*nn+n]
+
+			if q >= 0 {
+				// A real pair, rotate so the block becomes upper triangular.
+				if p >= 0 {
+					z = p + z
+				} else {
+					z = p - z
+				}
+				d[n-1] = x + z
+				d[n] = d[n-1]
+				if z != 0 {
+					d[n] = x - w/z
+				}
+				e[n-1] = 0
+				e[n] = 0
+				x = h[n*nn+n-1]
+				s = math.Abs(x) + math.Abs(z)
+				p = x / s
+				q = z / s
+				r = math.Sqrt(p*p + q*q)
+				p /= r
+				q /= r
+
+				for j := n - 1; j < nn; j++ {
+					z = h[(n-1)*nn+j]
+					h[(n-1)*nn+j] = q*z + p*h[n*nn+j]
+					h[n*nn+j] = q*h[n*nn+j] - p*z
+				}
+				for i := 0; i <= n; i++ {
+					z = h[i*nn+n-1]
+					h[i*nn+n-1] = q*z + p*h[i*nn+n]
+					h[i*nn+n] = q*h[i*nn+n] - p*z
+				}
+				for i := 0; i < nn; i++ {
+					z = v[i*nn+n-1]
+					v[i*nn+n-1] = q*z + p*v[i*nn+n]
+					v[i*nn+n] = q*v[i*nn+n] - p*z
+				}
+				h[n*nn+n-1] = 0
+			} else {
+				// A complex pair.
+				d[n-1] = x + p
+				d[n] = x + p
+				e[n-1] = z
+				e[n] = -z
+			}
+			if n > 1 {
+				h[(n-1)*nn+n-2] = 0
+			}
+			n -= 2
+			iter = 0
+		} else {
+			total++
+			if total > eigenMaxIterations*nn {
+				return nil, nil, errors.New("Eigenvalue decomposition did not converge")
+			}
+
+			// Form the shift.
+			x = h[n*nn+n]
+			y = 0
+			w = 0
+			if l < n {
+				y = h[(n-1)*nn+n-1]
+				w = h[n*nn+n-1] * h[(n-1)*nn+n]
+			}
+
+			// Wilkinson's original ad hoc shift.
+			if iter == 10 {
+				exshift += x
+				for i := 0; i <= n; i++ {
+					h[i*nn+i] -= x
+				}
+				s = math.Abs(h[n*nn+n-1]) + math.Abs(h[(n-1)*nn+n-2])
+				x = 0.75 * s
+				y = x
+				w = -0.4375 * s * s
+			}
+
+			// MATLAB's ad hoc shift.
+			if iter == 30 {
+				s = (y - x) / 2
+				s = s*s + w
+				if s > 0 {
+					s = math.Sqrt(s)
+					if y < x {
+						s = -s
+					}
+					s = x - w/((y-x)/2+s)
+					for i := 0; i <= n; i++ {
+						h[i*nn+i] -= s
+					}
+					exshift += s
+					x = 0.964
+					y = x
+					w = x
+				}
+			}
+			iter++
+
+			// Look for two consecutive small subdiagonal elements.
+			m := n - 2
+			for m >= l {
+				z = h[m*nn+m]
+				r = x - z
+				s = y - z
+				p = (r*s-w)/h[(m+1)*nn+m] + h[m*nn+m+1]
+				q = h[(m+1)*nn+m+1] - z - r - s
+				r = h[(m+2)*nn+m+1]
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p /= s
+				q /= s
+				r /= s
+				if m == l {
+					break
+				}
+				if math.Abs(h[m*nn+m-1])*(math.Abs(q)+math.Abs(r)) <
+					epsilon*(math.Abs(p)*(math.Abs(h[(m-1)*nn+m-1])+math.Abs(z)+math.Abs(h[(m+1)*nn+m+1]))) {
+					break
+				}
+				m--
+			}
+
+			for i := m + 2; i <= n; i++ {
+				h[i*nn+i-2] = 0
+				if i > m+2 {
+					h[i*nn+i-3] = 0
+				}
+			}
+
+			// Double QR step involving rows l:n and columns m:n.
+			for k := m; k <= n-1; k++ {
+				notlast := k != n-1
+				if k != m {
+					p = h[k*nn+k-1]
+					q = h[(k+1)*nn+k-1]
+					r = 0
+					if notlast {
+						r = h[(k+2)*nn+k-1]
+					}
+					x = math.Abs(p) + math.Abs(q) + math.Abs(r)
+					if x == 0 {
+						continue
+					}
+					p /= x
+					q /= x
+					r /= x
+				}
+
+				s = math.Sqrt(p*p + q*q + r*r)
+				if p < 0 {
+					s = -s
+				}
+				if s == 0 {
+					continue
+				}
+
+				if k != m {
+					h[k*nn+k-1] = -s * x
+				} else if l != m {
+					h[k*nn+k-1] = -h[k*nn+k-1]
+				}
+				p += s
+				x = p / s
+				y = q / s
+				z = r / s
+				q /= p
+				r /= p
+
+				for j := k; j < nn; j++ {
+					p = h[k*nn+j] + q*h[(k+1)*nn+j]
+					if notlast {
+						p += r * h[(k+2)*nn+j]
+						h[(k+2)*nn+j] -= p * z
+					}
+					h[k*nn+j] -= p * x
+					h[(k+1)*nn+j] -= p * y
+				}
+
+				for i := 0; i <= int(math.Min(float64(n), float64(k+3))); i++ {
+					p = x*h[i*nn+k] + y*h[i*nn+k+1]
+					if notlast {
+						p += z * h[i*nn+k+2]
+						h[i*nn+k+2] -= p * r
+					}
+					h[i*nn+k] -= p
+					h[i*nn+k+1] -= p * q
+				}
+
+				for i := 0; i < nn; i++ {
+					p = x*v[i*nn+k] + y*v[i*nn+k+1]
+					if notlast {
+						p += z * v[i*nn+k+2]
+						v[i*nn+k+2] -= p * r
+					}
+					v[i*nn+k] -= p
+					v[i*nn+k+1] -= p * q
+				}
+			}
+		}
+	}
+
+	return d, e, nil
+}
+
+// schurVectors computes the eigenvectors of the real Schur form H by back substitution and transforms them with V.
+func schurVectors(H, V *MatrixStruct, d, e []float64) {
+	nn := H.Rows
+	h := H.Elements
+	v := V.Elements
+	var p, q, r, s, t, w, x, y, z float64
+
+	norm := float64(0)
+	for i := 0; i < nn; i++ {
+		for j := int(math.Max(float64(i-1), 0)); j < nn; j++ {
+			norm += math.Abs(h[i*nn+j])
+		}
+	}
+	if norm == 0 {
+		return
+	}
+
+	for n := nn - 1; n >= 0; n-- {
+		p = d[n]
+		q = e[n]
+
+		if q == 0 {
+			// A real vector.
+			l := n
+			h[n*nn+n] = 1
+			for i := n - 1; i >= 0; i-- {
+				w = h[i*nn+i] - p
+				r = 0
+				for j := l; j <= n; j++ {
+					r += h[i*nn+j] * h[j*nn+n]
+				}
+				if e[i] < 0 {
+					z = w
+					s = r
+					continue
+				}
+
+				l = i
+				if e[i] == 0 {
+					if w != 0 {
+						h[i*nn+n] = -r / w
+					} else {
+						h[i*nn+n] = -r / (epsilon * norm)
+					}
+				} else {
+					x = h[i*nn+i+1]
+					y = h[(i+1)*nn+i]
+					q = (d[i]-p)*(d[i]-p) + e[i]*e[i]
+					t = (x*s - z*r) / q
+					h[i*nn+n] = t
+					if math.Abs(x) > math.Abs(z) {
+						h[(i+1)*nn+n] = (-r - w*t) / x
+					} else {
+						h[(i+1)*nn+n] = (-s - y*t) / z
+					}
+				}
+
+				// Overflow control.
+				t = math.Abs(h[i*nn+n])
+				if (epsilon*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						h[j*nn+n] /= t
+					}
+				}
+			}
+		} else if q < 0 {
+			// A complex vector, the last component is chosen to be imaginary so the system is triangular.
+			l := n - 1
+			if math.Abs(h[n*nn+n-1]) > math.Abs(h[(n-1)*nn+n]) {
+				h[(n-1)*nn+n-1] = q / h[n*nn+n-1]
+				h[(n-1)*nn+n] = -(h[n*nn+n] - p) / h[n*nn+n-1]
+			} else {
+				h[(n-1)*nn+n-1], h[(n-1)*nn+n] = complexDivide(0, -h[(n-1)*nn+n], h[(n-1)*nn+n-1]-p, q)
+			}
+			h[n*nn+n-1] = 0
+			h[n*nn+n] = 1
+
+			var ra, sa, vr, vi float64
+			for i := n - 2; i >= 0; i-- {
+				ra = 0
+				sa = 0
+				for j := l; j <= n; j++ {
+					ra += h[i*nn+j] * h[j*nn+n-1]
+					sa += h[i*nn+j] * h[j*nn+n]
+				}
+				w = h[i*nn+i] - p
+
+				if e[i] < 0 {
+					z = w
+					r = ra
+					s = sa
+					continue
+				}
+
+				l = i
+				if e[i] == 0 {
+					h[i*nn+n-1], h[i*nn+n] = complexDivide(-ra, -sa, w, q)
+				} else {
+					x = h[i*nn+i+1]
+					y = h[(i+1)*nn+i]
+					vr = (d[i]-p)*(d[i]-p) + e[i]*e[i] - q*q
+					vi = (d[i] - p) * 2 * q
+					if vr == 0 && vi == 0 {
+						vr = epsilon * norm * (math.Abs(w) + math.Abs(q) + math.Abs(x) + math.Abs(y) + math.Abs(z))
+					}
+					h[i*nn+n-1], h[i*nn+n] = complexDivide(x*r-z*ra+q*sa, x*s-z*sa-q*ra, vr, vi)
+					if math.Abs(x) > math.Abs(z)+math.Abs(q) {
+						h[(i+1)*nn+n-1] = (-ra - w*h[i*nn+n-1] + q*h[i*nn+n]) / x
+						h[(i+1)*nn+n] = (-sa - w*h[i*nn+n] - q*h[i*nn+n-1]) / x
+					} else {
+						h[(i+1)*nn+n-1], h[(i+1)*nn+n] = complexDivide(-r-y*h[i*nn+n-1], -s-y*h[i*nn+n], z, q)
+					}
+				}
+
+				// Overflow control.
+				t = math.Max(math.Abs(h[i*nn+n-1]), math.Abs(h[i*nn+n]))
+				if (epsilon*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						h[j*nn+n-1] /= t
+						h[j*nn+n] /= t
+					}
+				}
+			}
+		}
+	}
+
+	// Back transform to get the eigenvectors of the original matrix.
+	for j := nn - 1; j >= 0; j-- {
+		for i := 0; i < nn; i++ {
+			z = 0
+			for k := 0; k <= j; k++ {
+				z += v[i*nn+k] * h[k*nn+j]
+			}
+			v[i*nn+j] = z
+		}
+	}
+}
+
+// complexDivide returns the real and imaginary parts of (xr + i*xi) / (yr + i*yi) using Smith's algorithm to avoid overflow.
+func complexDivide(xr, xi, yr, yi float64) (float64, float64) {
+	if math.Abs(yr) > math.Abs(yi) {
+		r := yi / yr
+		d := yr + r*yi
+		return (xr + r*xi) / d, (xi - r*xr) / d
+	}
+	r := yr / yi
+	d := yi + r*yr
+	return (r*xr + xi) / d, (r*xi - xr) / d
+}
@@ -0,0 +1,79 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/cmplx"
+	"testing"
+)
+
+func TestEigen(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(2, 2, []float64{0, 1, -2, -3})
+	assert.Nil(err)
+
+	values, err := a.Eigen()
+	assert.Nil(err)
+	assert.Len(values, 2)
+	assert.InDelta(-3, real(values[0])+real(values[1]), 1e-12)
+	assert.InDelta(2, real(values[0])*real(values[1]), 1e-12)
+
+	b, err := Matrix(3, 3, []float64{0, -1, 0, 1, 0, 0, 0, 0, 2})
+	assert.Nil(err)
+
+	values, err = b.Eigen()
+	assert.Nil(err)
+	found := map[complex128]bool{}
+	for _, value := range values {
+		for _, expected := range []complex128{complex(0, 1), complex(0, -1), complex(2, 0)} {
+			if cmplx.Abs(value-expected) < 1e-12 {
+				found[expected] = true
+			}
+		}
+	}
+	assert.Len(found, 3)
+
+	c, err := Matrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	_, err = c.Eigen()
+	assert.NotNil(err)
+}
+
+func BenchmarkEigen(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	for n := 0; n < b.N; n++ {
+		_, _ = a.Eigen()
+	}
+}
+
+func TestEigenVectors(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(5, 5, []float64{
+		1, 2, 0, -1, 3,
+		-2, 1, 4, 0, 1,
+		0, -3, 2, 1, 0,
+		5, 0, -1, 3, 2,
+		1, 1, 1, -4, 0,
+	})
+	assert.Nil(err)
+
+	values, vectors, err := a.EigenVectors()
+	assert.Nil(err)
+	assert.Len(values, 5)
+	assert.Len(vectors, 5)
+
+	sum := complex128(0)
+	for i, value := range values {
+		sum += value
+		for r := 0; r < 5; r++ {
+			av := complex128(0)
+			for c := 0; c < 5; c++ {
+				av += complex(a.Elements[r*5+c], 0) * vectors[i][c]
+			}
+			assert.InDelta(0, cmplx.Abs(av-value*vectors[i][r]), 1e-10)
+		}
+	}
+	assert.InDelta(a.Trace(), real(sum), 1e-10)
+	assert.InDelta(0, imag(sum), 1e-10)
+}
@@ -0,0 +1,233 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// eigenMaxIterations limits the number of QL or QR iterations spent on a single eigenvalue before giving up.
+const eigenMaxIterations = 30
+
+// EigenSym will return the eigenvalues of a symmetric matrix in ascending order along with an orthogonal matrix of the corresponding eigenvectors.
+func (m MatrixStruct) EigenSym() (values []float64, vectors *MatrixStruct, err error) {
+	if !m.IsSymmetric() {
+		return nil, nil, errors.New("Not a symmetric matrix")
+	}
+
+	// Householder reflections reduce the matrix to tridiagonal form before the QL iteration.
+	vectors, d, e := m.tridiagonal()
+	if err := tridiagonalQL(vectors, d, e); err != nil {
+		return nil, nil, err
+	}
+
+	n := m.Rows
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return d[order[i]] < d[order[j]] })
+
+	values = make([]float64, n)
+	sorted, _ := Zeros(n, n)
+	for k, j := range order {
+		values[k] = d[j]
+		for i := 0; i < n; i++ {
+			sorted.Elements[i*n+k] = vectors.Elements[i*n+j]
+		}
+	}
+
+	return values, sorted, nil
+}
+
+// tridiagonal reduces a symmetric matrix to tridiagonal form T = Q^T*A*Q, returning Q with the diagonal d and subdiagonal e of T.
+func (m MatrixStruct) tridiagonal() (Q *MatrixStruct, d, e []float64) {
+	n := m.Rows
+	Q = m.Clone()
+	v := Q.Elements
+	d = make([]float64, n)
+	// e[i] = T[i][i-1], so e[0] stays zero. Only the lower triangle of the matrix is read.
+	e = make([]float64, n)
+
+	for j := 0; j < n; j++ {
+		d[j] = v[(n-1)*n+j]
+	}
+
+	for i := n - 1; i > 0; i-- {
+		scale := float64(0)
+		h := float64(0)
+		for k := 0; k < i; k++ {
+			scale += math.Abs(d[k])
+		}
+
+		if scale == 0 {
+			e[i] = d[i-1]
+			for j := 0; j < i; j++ {
+				d[j] = v[(i-1)*n+j]
+				v[i*n+j] = 0
+				v[j*n+i] = 0
+			}
+		} else {
+			for k := 0; k < i; k++ {
+				d[k] /= scale
+				h += d[k] * d[k]
+			}
+			f := d[i-1]
+			g := math.Sqrt(h)
+			if f > 0 {
+				g = -g
+			}
+			e[i] = scale * g
+			h -= f * g
+			d[i-1] = f - g
+			for j := 0; j < i; j++ {
+				e[j] = 0
+			}
+
+			for j := 0; j < i; j++ {
+				f = d[j]
+				v[j*n+i] = f
+				g = e[j] + v[j*n+j]*f
+				for k := j + 1; k <= i-1; k++ {
+					g += v[k*n+j] * d[k]
+					e[k] += v[k*n+j] * f
+				}
+				e[j] = g
+			}
+
+			f = 0
+			for j := 0; j < i; j++ {
+				e[j] /= h
+				f += e[j] * d[j]
+			}
+			hh := f / (h + h)
+			for j := 0; j < i; j++ {
+				e[j] -= hh * d[j]
+			}
+			for j := 0; j < i; j++ {
+				f = d[j]
+				g = e[j]
+				for k := j; k <= i-1; k++ {
+					v[k*n+j] -= f*e[k] + g*d[k]
+				}
+				d[j] = v[(i-1)*n+j]
+				v[i*n+j] = 0
+			}
+		}
+		d[i] = h
+	}
+
+	// Accumulate the Householder reflections into Q.
+	for i := 0; i < n-1; i++ {
+		v[(n-1)*n+i] = v[i*n+i]
+		v[i*n+i] = 1
+		h := d[i+1]
+		if h != 0 {
+			for k := 0; k <= i; k++ {
+				d[k] = v[k*n+i+1] / h
+			}
+			for j := 0; j <= i; j++ {
+				g := float64(0)
+				for k := 0; k <= i; k++ {
+					g += v[k*n+i+1] * v[k*n+j]
+				}
+				for k := 0; k <= i; k++ {
+					v[k*n+j] -= g * d[k]
+				}
+			}
+		}
+		for k := 0; k <= i; k++ {
+			v[k*n+i+1] = 0
+		}
+	}
+	for j := 0; j < n; j++ {
+		d[j] = v[(n-1)*n+j]
+		v[(n-1)*n+j] = 0
+	}
+	v[(n-1)*n+n-1] = 1
+	e[0] = 0
+
+	return
+}
+
+// tridiagonalQL diagonalises the symmetric tridiagonal matrix held in d and e using the implicit QL algorithm, applying the rotations to Q.
+func tridiagonalQL(Q *MatrixStruct, d, e []float64) error {
+	n := len(d)
+	v := Q.Elements
+
+	for i := 1; i < n; i++ {
+		e[i-1] = e[i]
+	}
+	e[n-1] = 0
+
+	f := float64(0)
+	tst1 := float64(0)
+	for l := 0; l < n; l++ {
+		tst1 = math.Max(tst1, math.Abs(d[l])+math.Abs(e[l]))
+		m := l
+		for m < n {
+			if math.Abs(e[m]) <= epsilon*tst1 {
+				break
+			}
+			m++
+		}
+
+		if m > l {
+			for iter := 0; ; iter++ {
+				if iter == eigenMaxIterations {
+					return errors.New("Eigenvalue decomposition did not converge")
+				}
+
+				g := d[l]
+				p := (d[l+1] - g) / (2 * e[l])
+				r := math.Hypot(p, 1)
+				if p < 0 {
+					r = -r
+				}
+				d[l] = e[l] / (p + r)
+				d[l+1] = e[l] * (p + r)
+				dl1 := d[l+1]
+				h := g - d[l]
+				for i := l + 2; i < n; i++ {
+					d[i] -= h
+				}
+				f += h
+
+				p = d[m]
+				c, c2, c3 := float64(1), float64(1), float64(1)
+				el1 := e[l+1]
+				s, s2 := float64(0), float64(0)
+				for i := m - 1; i >= l; i-- {
+					c3 = c2
+					c2 = c
+					s2 = s
+					g = c * e[i]
+					h = c * p
+					r = math.Hypot(p, e[i])
+					e[i+1] = s * r
+					s = e[i] / r
+					c = p / r
+					p = c*d[i] - s*g
+					d[i+1] = h + s*(c*g+s*d[i])
+
+					for k := 0; k < n; k++ {
+						h = v[k*n+i+1]
+						v[k*n+i+1] = s*v[k*n+i] + c*h
+						v[k*n+i] = c*v[k*n+i] - s*h
+					}
+				}
+				p = -s * s2 * c3 * el1 * e[l] / dl1
+				e[l] = s * p
+				d[l] = c * p
+
+				if math.Abs(e[l]) <= epsilon*tst1 {
+					break
+				}
+			}
+		}
+		d[l] += f
+		e[l] = 0
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEigenSym(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{2, -1, 0, -1, 2, -1, 0, -1, 2})
+	assert.Nil(err)
+
+	values, vectors, err := a.EigenSym()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0.5857864376269049, 2, 3.414213562373095}, values, 1e-12)
+
+	eye, _ := Eye(3, 3)
+	VTV, _ := vectors.Transpose().Multiply(vectors)
+	assert.InDeltaSlice(eye.Elements, VTV.Elements, 1e-12)
+
+	AV, _ := a.Multiply(vectors)
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			assert.InDelta(values[j]*vectors.Elements[i*3+j], AV.Elements[i*3+j], 1e-12)
+		}
+	}
+
+	b, err := Matrix(4, 4, []float64{4, 1, -2, 2, 1, 2, 0, 1, -2, 0, 3, -2, 2, 1, -2, -1})
+	assert.Nil(err)
+
+	values, vectors, err = b.EigenSym()
+	assert.Nil(err)
+	for i := 1; i < len(values); i++ {
+		assert.True(values[i-1] <= values[i])
+	}
+
+	D, _ := Zeros(4, 4)
+	for i, value := range values {
+		D.Elements[i*4+i] = value
+	}
+	VD, _ := vectors.Multiply(D)
+	B, _ := VD.Multiply(vectors.Transpose())
+	assert.InDeltaSlice(b.Elements, B.Elements, 1e-12)
+
+	c, err := Matrix(2, 2, []float64{1, 2, 3, 4})
+	assert.Nil(err)
+	_, _, err = c.EigenSym()
+	assert.NotNil(err)
+}
+
+func BenchmarkEigenSym(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{4, 1, -2, 2, 1, 2, 0, 1, -2, 0, 3, -2, 2, 1, -2, -1})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = a.EigenSym()
+	}
+}
@@ -0,0 +1,314 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// padeThetas are the largest 1-norms for which the Pade approximants of degree 3, 5, 7 and 9 give the exponential to double precision.
+var padeThetas = []float64{1.495585217958292e-2, 2.539398330063230e-1, 9.504178996162932e-1, 2.097847961257068}
+
+// padeCoefficients holds the numerator coefficients of the Pade approximants matching padeThetas.
+var padeCoefficients = [][]float64{
+	{120, 60, 12, 1},
+	{30240, 15120, 3360, 420, 30, 1},
+	{17297280, 8648640, 1995840, 277200, 25200, 1512, 56, 1},
+	{17643225600, 8821612800, 2075673600, 302702400, 30270240, 2162160, 110880, 3960, 90, 1},
+}
+
+// pade13Theta is the largest 1-norm for which the degree 13 Pade approximant is accurate.
+const pade13Theta = 5.371920351148152
+
+var pade13Coefficients = []float64{
+	64764752532480000, 32382376266240000, 7771770303897600, 1187353796428800, 129060195264000,
+	10559470521600, 670442572800, 33522128640, 1323241920, 40840800, 960960, 16380, 182, 1,
+}
+
+// sqrtMaxIterations limits the number of Denman-Beavers iterations.
+const sqrtMaxIterations = 100
+
+// logSquareRoots limits how many square roots Log will take to bring the matrix close to the identity.
+const logSquareRoots = 64
+
+// logPadeNodes is the number of Gauss-Legendre nodes in the Pade approximant of log(I + X), which is accurate when ||X||_1 is at most logPadeTheta.
+const (
+	logPadeNodes = 8
+	logPadeTheta = 0.25
+)
+
+// Exp will return the matrix exponential of a square matrix using scaling and squaring with Pade approximants.
+func (m MatrixStruct) Exp() (*MatrixStruct, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+	if !m.isFinite() {
+		return nil, errors.New("Matrix contains values that are not finite")
+	}
+
+	// The degree and the amount of scaling are chosen from the 1-norm to reach double precision with as few multiplications as possible.
+	norm := m.norm1()
+	for i, theta := range padeThetas {
+		if norm <= theta {
+			return m.padeExp(padeCoefficients[i])
+		}
+	}
+
+	// Larger matrices are scaled down by a power of two and the result is squared back up.
+	s := 0
+	A := m.Clone()
+	if norm > pade13Theta {
+		s = int(math.Ceil(math.Log2(norm / pade13Theta)))
+		A = A.ScalarMultiply(math.Ldexp(1, -s))
+	}
+
+	R, err := A.pade13Exp()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < s; i++ {
+		R, _ = R.Multiply(R)
+	}
+
+	if !R.isFinite() {
+		return nil, errors.New("Matrix exponential overflows")
+	}
+	return R, nil
+}
+
+// padeExp evaluates the Pade approximant of the exponential with coefficients b.
+func (m MatrixStruct) padeExp(b []float64) (*MatrixStruct, error) {
+	n := m.Rows
+	eye, _ := Eye(n, n)
+	A2, _ := m.Multiply(&m)
+
+	// The numerator p(A) = V + U is split into its even part V and odd part U, so the denominator is V - U.
+	U := eye.ScalarMultiply(b[1])
+	V := eye.ScalarMultiply(b[0])
+	power := eye
+	for k := 2; k < len(b); k += 2 {
+		power, _ = power.Multiply(A2)
+		V.addScaled(b[k], power)
+		U.addScaled(b[k+1], power)
+	}
+	U, _ = m.Multiply(U)
+
+	return padeQuotient(U, V)
+}
+
+// pade13Exp evaluates the degree 13 Pade approximant, grouping the powers so that only six matrix multiplications are needed.
+func (m MatrixStruct) pade13Exp() (*MatrixStruct, error) {
+	b := pade13Coefficients
+	n := m.Rows
+	eye, _ := Eye(n, n)
+	A2, _ := m.Multiply(&m)
+	A4, _ := A2.Multiply(A2)
+	A6, _ := A4.Multiply(A2)
+
+	U := A6.ScalarMultiply(b[13])
+	U.addScaled(b[11], A4)
+	U.addScaled(b[9], A2)
+	U, _ = A6.Multiply(U)
+	U.addScaled(b[7], A6)
+	U.addScaled(b[5], A4)
+	U.addScaled(b[3], A2)
+	U.addScaled(b[1], eye)
+	U, _ = m.Multiply(U)
+
+	V := A6.ScalarMultiply(b[12])
+	V.addScaled(b[10], A4)
+	V.addScaled(b[8], A2)
+	V, _ = A6.Multiply(V)
+	V.addScaled(b[6], A6)
+	V.addScaled(b[4], A4)
+	V.addScaled(b[2], A2)
+	V.addScaled(b[0], eye)
+
+	return padeQuotient(U, V)
+}
+
+// padeQuotient solves (V - U)*R = V + U for R.
+func padeQuotient(U, V *MatrixStruct) (*MatrixStruct, error) {
+	P, _ := V.Add(U)
+	Q, _ := V.Subtract(U)
+
+	f := Q.luDecompose()
+	if f.singular {
+		return nil, errors.New("Matrix is singular")
+	}
+	return f.solve(P)
+}
+
+// Sqrt will return the principal square root of a square matrix, or an error if it has an eigenvalue on the closed negative real axis.
+func (m MatrixStruct) Sqrt() (*MatrixStruct, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+	if err := m.checkPrincipalDomain(); err != nil {
+		return nil, err
+	}
+	return m.denmanBeavers()
+}
+
+// Log will return the principal logarithm of a square matrix, or an error if it has an eigenvalue on the closed negative real axis.
+func (m MatrixStruct) Log() (*MatrixStruct, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+	if err := m.checkPrincipalDomain(); err != nil {
+		return nil, err
+	}
+
+	// Square roots are taken until the matrix is close to the identity, and the logarithm found there is scaled back up.
+	n := m.Rows
+	eye, _ := Eye(n, n)
+	A := m.Clone()
+	X, _ := A.Subtract(eye)
+	k := 0
+	for X.norm1() > logPadeTheta {
+		if k == logSquareRoots {
+			return nil, errors.New("Matrix logarithm did not converge")
+		}
+
+		var err error
+		A, err = A.denmanBeavers()
+		if err != nil {
+			return nil, err
+		}
+		X, _ = A.Subtract(eye)
+		k++
+	}
+
+	// log(I + X) = X * sum w_j (I + t_j*X)^-1, the partial fraction form of the diagonal Pade approximant.
+	L, _ := Zeros(n, n)
+	nodes, weights := gaussLegendre(logPadeNodes)
+	for j, t := range nodes {
+		D := X.ScalarMultiply(t)
+		D.addScaled(1, eye)
+		f := D.luDecompose()
+		if f.singular {
+			return nil, errors.New("Matrix is singular")
+		}
+		Y, err := f.solve(X)
+		if err != nil {
+			return nil, err
+		}
+		L.addScaled(weights[j], Y)
+	}
+
+	return L.ScalarMultiply(math.Ldexp(1, k)), nil
+}
+
+// denmanBeavers returns the principal square root of the matrix using the scaled Denman-Beavers iteration.
+func (m MatrixStruct) denmanBeavers() (*MatrixStruct, error) {
+	n := m.Rows
+	eye, _ := Eye(n, n)
+	// Y <- (Y + Z^-1)/2 and Z <- (Z + Y^-1)/2 converge to Y = m^1/2 and Z = m^-1/2.
+	Y := m.Clone()
+	Z := eye.Clone()
+
+	converged := false
+	for iter := 0; iter < sqrtMaxIterations; iter++ {
+		fy := Y.luDecompose()
+		fz := Z.luDecompose()
+		if fy.singular || fz.singular {
+			return nil, errors.New("Matrix is singular")
+		}
+
+		// Scaling by |det(Y)*det(Z)|^(-1/2n) shortens the slow initial phase for badly conditioned matrices.
+		gamma := float64(1)
+		if !converged {
+			logDet := float64(0)
+			for i := 0; i < n; i++ {
+				logDet += math.Log(math.Abs(fy.lu.Elements[i*n+i])) + math.Log(math.Abs(fz.lu.Elements[i*n+i]))
+			}
+			gamma = math.Exp(-logDet / float64(2*n))
+		}
+
+		Yinv, _ := fy.solve(eye)
+		Zinv, _ := fz.solve(eye)
+
+		Ynext := Y.ScalarMultiply(gamma / 2)
+		Ynext.addScaled(1/(2*gamma), Zinv)
+		Znext := Z.ScalarMultiply(gamma / 2)
+		Znext.addScaled(1/(2*gamma), Yinv)
+
+		diff, _ := Ynext.Subtract(Y)
+		Y, Z = Ynext, Znext
+
+		// The iteration converges quadratically, so one more step after reaching the square root of epsilon gives full accuracy.
+		if converged {
+			return Y, nil
+		}
+		if diff.frobenius() <= math.Sqrt(epsilon)*Y.frobenius() {
+			converged = true
+		}
+	}
+
+	return nil, errors.New("Square root iteration did not converge")
+}
+
+// checkPrincipalDomain returns an error if the matrix has an eigenvalue on the closed negative real axis.
+func (m MatrixStruct) checkPrincipalDomain() error {
+	values, err := m.Eigen()
+	if err != nil {
+		return err
+	}
+
+	// The principal square root and logarithm are not defined as real matrices there.
+	tol := float64(m.Rows) * epsilon * m.maxAbs()
+	for _, value := range values {
+		if real(value) <= tol && math.Abs(imag(value)) <= tol {
+			return errors.New("Matrix has an eigenvalue on the closed negative real axis")
+		}
+	}
+	return nil
+}
+
+// gaussLegendre returns the nodes and weights of the n point Gauss-Legendre rule on [0, 1].
+func gaussLegendre(n int) (nodes, weights []float64) {
+	nodes = make([]float64, n)
+	weights = make([]float64, n)
+
+	legendre := func(x float64) (p, dp float64) {
+		p0, p1 := float64(1), x
+		for k := 2; k <= n; k++ {
+			p0, p1 = p1, (float64(2*k-1)*x*p1-float64(k-1)*p0)/float64(k)
+		}
+		return p1, float64(n) * (x*p1 - p0) / (x*x - 1)
+	}
+
+	// Newton's method finds the roots of the Legendre polynomial.
+	for i := 0; i < n; i++ {
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		for iter := 0; iter < 100; iter++ {
+			p, dp := legendre(x)
+			dx := p / dp
+			x -= dx
+			if math.Abs(dx) <= epsilon {
+				break
+			}
+		}
+
+		_, dp := legendre(x)
+		nodes[i] = (1 - x) / 2
+		weights[i] = 1 / ((1 - x*x) * dp * dp)
+	}
+	return nodes, weights
+}
+
+// addScaled replaces m with m + s*n, the matrices must have the same shape.
+func (m MatrixStruct) addScaled(s float64, n *MatrixStruct) {
+	for i, value := range n.Elements {
+		m.Elements[i] += s * value
+	}
+}
+
+// isFinite reports whether every element of the matrix is neither NaN nor infinite.
+func (m MatrixStruct) isFinite() bool {
+	for _, value := range m.Elements {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return false
+		}
+	}
+	return true
+}
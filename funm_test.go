@@ -0,0 +1,142 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestExp(t *testing.T) {
+	assert := assert.New(t)
+
+	zero, _ := Zeros(3, 3)
+	eye, _ := Eye(3, 3)
+	E, err := zero.Exp()
+	assert.Nil(err)
+	assert.InDeltaSlice(eye.Elements, E.Elements, 1e-15)
+
+	a, err := Matrix(2, 2, []float64{0, 1, -1, 0})
+	assert.Nil(err)
+	for _, s := range []float64{0.01, 0.2, 0.9, 2, 4, 30} {
+		E, err = a.ScalarMultiply(s).Exp()
+		assert.Nil(err)
+		assert.InDeltaSlice([]float64{math.Cos(s), math.Sin(s), -math.Sin(s), math.Cos(s)}, E.Elements, 1e-12)
+	}
+
+	b, err := Matrix(2, 2, []float64{10, 200, 0, 10})
+	assert.Nil(err)
+	E, err = b.Exp()
+	assert.Nil(err)
+	e10 := math.Exp(10)
+	assert.InDeltaSlice([]float64{1, 200, 0, 1}, E.ScalarMultiply(1/e10).Elements, 1e-11)
+
+	c, err := Matrix(3, 3, []float64{1, 2, 0, -1, 0, 3, 0, 1, -2})
+	assert.Nil(err)
+	E, err = c.Exp()
+	assert.Nil(err)
+	negative, err := c.ScalarMultiply(-1).Exp()
+	assert.Nil(err)
+	product, _ := E.Multiply(negative)
+	assert.InDeltaSlice(eye.Elements, product.Elements, 1e-12)
+
+	wide, _ := Ones(2, 3)
+	_, err = wide.Exp()
+	assert.NotNil(err)
+
+	huge, _ := Matrix(1, 1, []float64{1000})
+	_, err = huge.Exp()
+	assert.NotNil(err)
+}
+
+func BenchmarkExp(b *testing.B) {
+	m, _ := Matrix(3, 3, []float64{1, 2, 0, -1, 0, 3, 0, 1, -2})
+	for n := 0; n < b.N; n++ {
+		_, _ = m.Exp()
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{4, 1, 0, 1, 3, 1, 0, 1, 2})
+	assert.Nil(err)
+	S, err := a.Sqrt()
+	assert.Nil(err)
+	square, _ := S.Multiply(S)
+	assert.InDeltaSlice(a.Elements, square.Elements, 1e-12)
+	assert.True(S.IsSymmetric())
+
+	// A rotation by a quarter turn has eigenvalues +-i, its principal square root is the rotation by an eighth.
+	b, err := Matrix(2, 2, []float64{0, -1, 1, 0})
+	assert.Nil(err)
+	S, err = b.Sqrt()
+	assert.Nil(err)
+	c := math.Sqrt(0.5)
+	assert.InDeltaSlice([]float64{c, -c, c, c}, S.Elements, 1e-12)
+
+	d, err := Matrix(2, 2, []float64{1e4, 1, 0, 1e-4})
+	assert.Nil(err)
+	S, err = d.Sqrt()
+	assert.Nil(err)
+	square, _ = S.Multiply(S)
+	assert.InDeltaSlice(d.Elements, square.Elements, 1e-9)
+
+	negative, err := Matrix(2, 2, []float64{-1, 0, 0, 4})
+	assert.Nil(err)
+	_, err = negative.Sqrt()
+	assert.NotNil(err)
+
+	singular, err := Matrix(2, 2, []float64{1, 1, 1, 1})
+	assert.Nil(err)
+	_, err = singular.Sqrt()
+	assert.NotNil(err)
+}
+
+func BenchmarkSqrt(b *testing.B) {
+	m, _ := Matrix(3, 3, []float64{4, 1, 0, 1, 3, 1, 0, 1, 2})
+	for n := 0; n < b.N; n++ {
+		_, _ = m.Sqrt()
+	}
+}
+
+func TestLog(t *testing.T) {
+	assert := assert.New(t)
+
+	eye, _ := Eye(3, 3)
+	L, err := eye.Log()
+	assert.Nil(err)
+	zero, _ := Zeros(3, 3)
+	assert.InDeltaSlice(zero.Elements, L.Elements, 1e-15)
+
+	d, err := Matrix(2, 2, []float64{math.E, 0, 0, 100})
+	assert.Nil(err)
+	L, err = d.Log()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1, 0, 0, math.Log(100)}, L.Elements, 1e-12)
+
+	a, err := Matrix(3, 3, []float64{1, 2, 0, -1, 0, 3, 0, 1, -2})
+	assert.Nil(err)
+	E, err := a.Exp()
+	assert.Nil(err)
+	L, err = E.Log()
+	assert.Nil(err)
+	assert.InDeltaSlice(a.Elements, L.Elements, 1e-10)
+
+	b, err := Matrix(2, 2, []float64{0, -1, 1, 0})
+	assert.Nil(err)
+	L, err = b.Log()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0, -math.Pi / 2, math.Pi / 2, 0}, L.Elements, 1e-12)
+
+	negative, err := Matrix(2, 2, []float64{-2, 0, 0, 3})
+	assert.Nil(err)
+	_, err = negative.Log()
+	assert.NotNil(err)
+}
+
+func BenchmarkLog(b *testing.B) {
+	m, _ := Matrix(3, 3, []float64{4, 1, 0, 1, 3, 1, 0, 1, 2})
+	for n := 0; n < b.N; n++ {
+		_, _ = m.Log()
+	}
+}
@@ -0,0 +1,238 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// Givens is a plane rotation G = [C S; -S C] acting on rows or columns I and K of a matrix.
+type Givens struct {
+	C, S float64
+	I, K int
+}
+
+// NewGivens will return the rotation acting on indexes i and k that maps the vector (a, b) to (r, 0), along with r.
+func NewGivens(a, b float64, i, k int) (Givens, float64) {
+	if b == 0 {
+		return Givens{C: 1, S: 0, I: i, K: k}, a
+	}
+
+	r := math.Hypot(a, b)
+	return Givens{C: a / r, S: b / r, I: i, K: k}, r
+}
+
+// ApplyLeft will replace m with G*m, which only changes rows I and K.
+func (g Givens) ApplyLeft(m *MatrixStruct) {
+	for j := 0; j < m.Columns; j++ {
+		a := m.Elements[g.I*m.Columns+j]
+		b := m.Elements[g.K*m.Columns+j]
+		m.Elements[g.I*m.Columns+j] = g.C*a + g.S*b
+		m.Elements[g.K*m.Columns+j] = -g.S*a + g.C*b
+	}
+}
+
+// ApplyRight will replace m with m*G^T, which only changes columns I and K.
+func (g Givens) ApplyRight(m *MatrixStruct) {
+	for i := 0; i < m.Rows; i++ {
+		a := m.Elements[i*m.Columns+g.I]
+		b := m.Elements[i*m.Columns+g.K]
+		m.Elements[i*m.Columns+g.I] = g.C*a + g.S*b
+		m.Elements[i*m.Columns+g.K] = -g.S*a + g.C*b
+	}
+}
+
+// rotate computes the rotation that zeroes R[k][column] against R[i][column] and applies it to both factors.
+func rotate(Q, R *MatrixStruct, i, k, column int) {
+	g, _ := NewGivens(R.Elements[i*R.Columns+column], R.Elements[k*R.Columns+column], i, k)
+	// Rotating the rows of R and the columns of Q leaves Q*R unchanged.
+	g.ApplyLeft(R)
+	g.ApplyRight(Q)
+	R.Elements[k*R.Columns+column] = 0
+}
+
+// QRGivens will return the QR decomposition of the selected matrix using Givens rotations.
+func (m MatrixStruct) QRGivens() (Q, R *MatrixStruct) {
+	Q, _ = Eye(m.Rows, m.Rows)
+	R = m.Clone()
+
+	// Each rotation only touches two rows and zeros are skipped, which suits matrices that are already nearly triangular.
+	for j := 0; j < m.Columns && j < m.Rows-1; j++ {
+		for i := m.Rows - 1; i > j; i-- {
+			if R.Elements[i*R.Columns+j] != 0 {
+				rotate(Q, R, i-1, i, j)
+			}
+		}
+	}
+	return
+}
+
+// QRInsertRow will update the full QR decomposition A = Q*R to the decomposition of A with row inserted before row k.
+func QRInsertRow(Q, R *MatrixStruct, k int, row *MatrixStruct) (*MatrixStruct, *MatrixStruct, error) {
+	rows, cols := R.Rows, R.Columns
+	if k < 0 || k > rows {
+		return nil, nil, errors.New("Matrix index is out of range")
+	}
+	if len(row.Elements) != cols {
+		return nil, nil, errors.New("matrix dimensions do not agree")
+	}
+
+	R1, _ := Zeros(rows+1, cols)
+	copy(R1.Elements, R.Elements)
+	copy(R1.Elements[rows*cols:], row.Elements)
+
+	Q1, _ := Zeros(rows+1, rows+1)
+	for i := 0; i < rows; i++ {
+		copy(Q1.Elements[i*(rows+1):i*(rows+1)+rows], Q.Elements[i*rows:(i+1)*rows])
+	}
+	Q1.Elements[rows*(rows+1)+rows] = 1
+
+	for j := 0; j < cols && j < rows; j++ {
+		rotate(Q1, R1, j, rows, j)
+	}
+
+	// The new row was factored as the last row, move it into place.
+	Q2, _ := Zeros(rows+1, rows+1)
+	for i := 0; i <= rows; i++ {
+		source := i
+		switch {
+		case i == k:
+			source = rows
+		case i > k:
+			source = i - 1
+		}
+		copy(Q2.Elements[i*(rows+1):(i+1)*(rows+1)], Q1.Elements[source*(rows+1):(source+1)*(rows+1)])
+	}
+
+	return Q2, R1, nil
+}
+
+// QRDeleteRow will update the full QR decomposition A = Q*R to the decomposition of A with row k removed.
+func QRDeleteRow(Q, R *MatrixStruct, k int) (*MatrixStruct, *MatrixStruct, error) {
+	rows, cols := R.Rows, R.Columns
+	if k < 0 || k >= rows {
+		return nil, nil, errors.New("Matrix index is out of range")
+	}
+	if rows < 2 {
+		return nil, nil, errors.New("Cannot delete the only row of a matrix")
+	}
+
+	Q1 := Q.Clone()
+	R1 := R.Clone()
+
+	// Rotate row k of Q into a multiple of e1, which turns R into an upper Hessenberg matrix.
+	q, _ := Zeros(rows, 1)
+	copy(q.Elements, Q.Elements[k*rows:(k+1)*rows])
+	for i := rows - 1; i > 0; i-- {
+		g, _ := NewGivens(q.Elements[i-1], q.Elements[i], i-1, i)
+		g.ApplyLeft(q)
+		g.ApplyLeft(R1)
+		g.ApplyRight(Q1)
+	}
+
+	// Row k of Q is now +-e1, so the first column of Q and the first row of R only contribute to the deleted row of A.
+	Q2, _ := Zeros(rows-1, rows-1)
+	for i, r := 0, 0; i < rows; i++ {
+		if i == k {
+			continue
+		}
+		copy(Q2.Elements[r*(rows-1):(r+1)*(rows-1)], Q1.Elements[i*rows+1:(i+1)*rows])
+		r++
+	}
+
+	R2, _ := Zeros(rows-1, cols)
+	copy(R2.Elements, R1.Elements[cols:])
+	return Q2, R2, nil
+}
+
+// QRInsertColumn will update the full QR decomposition A = Q*R to the decomposition of A with column inserted before column k.
+func QRInsertColumn(Q, R *MatrixStruct, k int, column *MatrixStruct) (*MatrixStruct, *MatrixStruct, error) {
+	rows, cols := R.Rows, R.Columns
+	if k < 0 || k > cols {
+		return nil, nil, errors.New("Matrix index is out of range")
+	}
+	if len(column.Elements) != rows {
+		return nil, nil, errors.New("matrix dimensions do not agree")
+	}
+
+	c, _ := Matrix(rows, 1, append([]float64(nil), column.Elements...))
+	w, _ := Q.Transpose().Multiply(c)
+
+	R1, _ := Zeros(rows, cols+1)
+	for i := 0; i < rows; i++ {
+		for j := 0; j <= cols; j++ {
+			switch {
+			case j < k:
+				R1.Elements[i*(cols+1)+j] = R.Elements[i*cols+j]
+			case j == k:
+				R1.Elements[i*(cols+1)+j] = w.Elements[i]
+			default:
+				R1.Elements[i*(cols+1)+j] = R.Elements[i*cols+j-1]
+			}
+		}
+	}
+
+	Q1 := Q.Clone()
+	for i := rows - 1; i > k; i-- {
+		rotate(Q1, R1, i-1, i, k)
+	}
+	return Q1, R1, nil
+}
+
+// QRDeleteColumn will update the full QR decomposition A = Q*R to the decomposition of A with column k removed.
+func QRDeleteColumn(Q, R *MatrixStruct, k int) (*MatrixStruct, *MatrixStruct, error) {
+	rows, cols := R.Rows, R.Columns
+	if k < 0 || k >= cols {
+		return nil, nil, errors.New("Matrix index is out of range")
+	}
+	if cols < 2 {
+		return nil, nil, errors.New("Cannot delete the only column of a matrix")
+	}
+
+	R1, _ := Zeros(rows, cols-1)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols-1; j++ {
+			source := j
+			if j >= k {
+				source = j + 1
+			}
+			R1.Elements[i*(cols-1)+j] = R.Elements[i*cols+source]
+		}
+	}
+
+	// Removing the column leaves R upper Hessenberg from column k onwards.
+	Q1 := Q.Clone()
+	for j := k; j < cols-1 && j < rows-1; j++ {
+		rotate(Q1, R1, j, j+1, j)
+	}
+	return Q1, R1, nil
+}
+
+// QRRankOneUpdate will update the full QR decomposition A = Q*R to the decomposition of A + u*v^T.
+func QRRankOneUpdate(Q, R, u, v *MatrixStruct) (*MatrixStruct, *MatrixStruct, error) {
+	rows, cols := R.Rows, R.Columns
+	if len(u.Elements) != rows || len(v.Elements) != cols {
+		return nil, nil, errors.New("matrix dimensions do not agree")
+	}
+
+	uc, _ := Matrix(rows, 1, append([]float64(nil), u.Elements...))
+	w, _ := Q.Transpose().Multiply(uc)
+	Q1 := Q.Clone()
+	R1 := R.Clone()
+
+	// Reduce w to a multiple of e1, which turns R into an upper Hessenberg matrix.
+	for i := rows - 1; i > 0; i-- {
+		g, _ := NewGivens(w.Elements[i-1], w.Elements[i], i-1, i)
+		g.ApplyLeft(w)
+		g.ApplyLeft(R1)
+		g.ApplyRight(Q1)
+	}
+
+	for j := 0; j < cols; j++ {
+		R1.Elements[j] += w.Elements[0] * v.Elements[j]
+	}
+
+	for j := 0; j < cols && j < rows-1; j++ {
+		rotate(Q1, R1, j, j+1, j)
+	}
+	return Q1, R1, nil
+}
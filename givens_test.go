@@ -0,0 +1,165 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func assertQR(assert *assert.Assertions, a, Q, R *MatrixStruct) {
+	assert.Equal(a.Rows, Q.Rows)
+	assert.Equal(a.Rows, Q.Columns)
+	assert.Equal(a.Rows, R.Rows)
+	assert.Equal(a.Columns, R.Columns)
+
+	for i := 0; i < R.Rows; i++ {
+		for j := 0; j < i && j < R.Columns; j++ {
+			assert.InDelta(0, R.Elements[i*R.Columns+j], 1e-12)
+		}
+	}
+
+	QR, _ := Q.Multiply(R)
+	assert.InDeltaSlice(a.Elements, QR.Elements, 1e-12)
+
+	QTQ, _ := Q.Transpose().Multiply(Q)
+	eye, _ := Eye(Q.Rows, Q.Rows)
+	assert.InDeltaSlice(eye.Elements, QTQ.Elements, 1e-12)
+}
+
+func TestGivens(t *testing.T) {
+	assert := assert.New(t)
+
+	g, r := NewGivens(3, 4, 0, 1)
+	assert.InDelta(5, r, 1e-15)
+
+	v, err := Vector(3, 4)
+	assert.Nil(err)
+	g.ApplyLeft(v)
+	assert.InDeltaSlice([]float64{5, 0}, v.Elements, 1e-15)
+
+	a, err := Matrix(2, 2, []float64{1, 2, 3, 4})
+	assert.Nil(err)
+	Q, _ := Eye(2, 2)
+	R := a.Clone()
+	g.ApplyLeft(R)
+	g.ApplyRight(Q)
+	QR, _ := Q.Multiply(R)
+	assert.InDeltaSlice(a.Elements, QR.Elements, 1e-15)
+
+	g, r = NewGivens(2, 0, 0, 1)
+	assert.Equal(float64(2), r)
+	assert.Equal(float64(1), g.C)
+	assert.Equal(float64(0), g.S)
+}
+
+func TestQRGivens(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 10, 1, 0, 1})
+	assert.Nil(err)
+
+	Q, R := a.QRGivens()
+	assertQR(assert, a, Q, R)
+}
+
+func BenchmarkQRGivens(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	for n := 0; n < b.N; n++ {
+		_, _ = a.QRGivens()
+	}
+}
+
+func TestQRInsertRow(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	Q, R := a.QRGivens()
+
+	row, err := Vector(7, 9)
+	assert.Nil(err)
+	Q1, R1, err := QRInsertRow(Q, R, 1, row)
+	assert.Nil(err)
+
+	expected, _ := Matrix(4, 2, []float64{1, 2, 7, 9, 3, 4, 5, 6})
+	assertQR(assert, expected, Q1, R1)
+
+	_, _, err = QRInsertRow(Q, R, 5, row)
+	assert.NotNil(err)
+}
+
+func TestQRDeleteRow(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 2, []float64{1, 2, 7, 9, 3, 4, 5, 6})
+	assert.Nil(err)
+	Q, R := a.QRGivens()
+
+	Q1, R1, err := QRDeleteRow(Q, R, 1)
+	assert.Nil(err)
+
+	expected, _ := Matrix(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	assertQR(assert, expected, Q1, R1)
+
+	_, _, err = QRDeleteRow(Q, R, 4)
+	assert.NotNil(err)
+}
+
+func TestQRInsertColumn(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	Q, R := a.QRGivens()
+
+	column, err := Vector(1, 0, -1)
+	assert.Nil(err)
+	Q1, R1, err := QRInsertColumn(Q, R, 0, column)
+	assert.Nil(err)
+
+	expected, _ := Matrix(3, 3, []float64{1, 1, 2, 0, 3, 4, -1, 5, 6})
+	assertQR(assert, expected, Q1, R1)
+
+	short, err := Vector(1, 2)
+	assert.Nil(err)
+	_, _, err = QRInsertColumn(Q, R, 0, short)
+	assert.NotNil(err)
+}
+
+func TestQRDeleteColumn(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{1, 1, 2, 0, 3, 4, -1, 5, 6})
+	assert.Nil(err)
+	Q, R := a.QRGivens()
+
+	Q1, R1, err := QRDeleteColumn(Q, R, 0)
+	assert.Nil(err)
+
+	expected, _ := Matrix(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	assertQR(assert, expected, Q1, R1)
+
+	_, _, err = QRDeleteColumn(Q, R, 3)
+	assert.NotNil(err)
+}
+
+func TestQRRankOneUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{4, 1, 2, 0, 3, 4, -1, 5, 6})
+	assert.Nil(err)
+	Q, R := a.QRGivens()
+
+	u, _ := Vector(1, 2, 3)
+	v, _ := Vector(-1, 0, 2)
+	Q1, R1, err := QRRankOneUpdate(Q, R, u, v)
+	assert.Nil(err)
+
+	uv, _ := u.Multiply(v.Transpose())
+	expected, _ := a.Add(uv)
+	assertQR(assert, expected, Q1, R1)
+
+	short, err := Vector(1, 2)
+	assert.Nil(err)
+	_, _, err = QRRankOneUpdate(Q, R, short, v)
+	assert.NotNil(err)
+}
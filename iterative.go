@@ -0,0 +1,488 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// LinearOperator is anything that can multiply a vector, which is all the iterative solvers need from a matrix.
+type LinearOperator interface {
+	// Dims returns the number of rows and columns of the operator.
+	Dims() (rows, columns int)
+	// MulVec writes the product of the operator and x into dst, which has one element per row.
+	MulVec(dst, x []float64)
+}
+
+// Preconditioner approximates the inverse of a matrix M that is close to A and cheap to solve with.
+type Preconditioner interface {
+	// Precondition writes the solution z of M*z = r into z.
+	Precondition(z, r []float64)
+}
+
+// IterativeSettings controls the iterative solvers, the zero value or a nil pointer selects the defaults.
+type IterativeSettings struct {
+	// Tolerance is the relative residual ||b - A*x||/||b|| at which the solver stops, 1e-10 when zero.
+	Tolerance float64
+	// AbsoluteTolerance stops the solver once the residual norm is below it, which matters when b is tiny.
+	AbsoluteTolerance float64
+	// MaxIterations caps the number of iterations, 10 times the size of the system when zero. For GMRES every inner step counts as an iteration.
+	MaxIterations int
+	// Restart is the number of GMRES steps between restarts, 30 when zero.
+	Restart int
+	// Preconditioner is applied on the left for CG and MINRES and on the right for GMRES and BiCGSTAB, nil means no preconditioning.
+	Preconditioner Preconditioner
+	// InitialGuess is the starting value of x, zero when nil.
+	InitialGuess []float64
+}
+
+// IterativeResult is the outcome of an iterative solve.
+type IterativeResult struct {
+	// X is the approximate solution.
+	X []float64
+	// Iterations is the number of iterations that were run.
+	Iterations int
+	// Residual is the final residual norm.
+	Residual float64
+	// History holds the residual norm before the first iteration and after every iteration.
+	History []float64
+	// Converged reports whether the tolerance was reached.
+	Converged bool
+}
+
+// defaultRestart is the GMRES restart length used when the settings do not give one.
+const defaultRestart = 30
+
+// Dims will return the number of rows and columns of the matrix, which together with MulVec makes MatrixStruct a LinearOperator.
+func (m MatrixStruct) Dims() (rows, columns int) {
+	return m.Rows, m.Columns
+}
+
+// MulVec will write the product of the matrix and the vector x into dst.
+func (m MatrixStruct) MulVec(dst, x []float64) {
+	for i := 0; i < m.Rows; i++ {
+		sum := float64(0)
+		for j, value := range m.Elements[i*m.Columns : (i+1)*m.Columns] {
+			sum += value * x[j]
+		}
+		dst[i] = sum
+	}
+}
+
+// CG will solve A*x = b with the preconditioned conjugate gradient method for a symmetric positive definite A and preconditioner.
+func CG(A LinearOperator, b []float64, settings *IterativeSettings) (*IterativeResult, error) {
+	s, x, r, err := startIterative(A, b, settings)
+	if err != nil {
+		return nil, err
+	}
+	result := &IterativeResult{X: x, History: []float64{vectorNorm(r)}}
+	tol := s.threshold(vectorNorm(b))
+	if result.History[0] <= tol {
+		return result.finish(true)
+	}
+
+	// In exact arithmetic the method finishes in at most n iterations.
+	n := len(b)
+	z := make([]float64, n)
+	s.precondition(z, r)
+	p := append([]float64(nil), z...)
+	Ap := make([]float64, n)
+	rz := dot(r, z)
+
+	for result.Iterations < s.MaxIterations {
+		result.Iterations++
+		A.MulVec(Ap, p)
+		pAp := dot(p, Ap)
+		if pAp <= 0 {
+			return result.fail("Matrix is not positive definite")
+		}
+
+		alpha := rz / pAp
+		axpy(alpha, p, x)
+		axpy(-alpha, Ap, r)
+
+		norm := vectorNorm(r)
+		result.History = append(result.History, norm)
+		if norm <= tol {
+			return result.finish(true)
+		}
+
+		s.precondition(z, r)
+		rzNext := dot(r, z)
+		beta := rzNext / rz
+		rz = rzNext
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+	return result.finish(false)
+}
+
+// MINRES will solve A*x = b for a symmetric, possibly indefinite, A with the minimum residual method of Paige and Saunders.
+func MINRES(A LinearOperator, b []float64, settings *IterativeSettings) (*IterativeResult, error) {
+	s, x, r1, err := startIterative(A, b, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(b)
+	y := make([]float64, n)
+	s.precondition(y, r1)
+	beta1 := dot(r1, y)
+	if beta1 < 0 {
+		return nil, errors.New("Preconditioner is not positive definite")
+	}
+	beta1 = math.Sqrt(beta1)
+
+	// The history holds the residual in the norm defined by the preconditioner, which is what the method minimises,
+	// so the tolerance is measured in the same norm.
+	bm := make([]float64, n)
+	s.precondition(bm, b)
+	tol := s.threshold(math.Sqrt(math.Max(dot(b, bm), 0)))
+
+	result := &IterativeResult{X: x, History: []float64{beta1}}
+	if beta1 <= tol {
+		return result.finish(true)
+	}
+
+	r2 := append([]float64(nil), r1...)
+	v := make([]float64, n)
+	w := make([]float64, n)
+	w1 := make([]float64, n)
+	w2 := make([]float64, n)
+
+	oldb, beta := float64(0), beta1
+	dbar, epsln := float64(0), float64(0)
+	phibar := beta1
+	cs, sn := float64(-1), float64(0)
+
+	for result.Iterations < s.MaxIterations {
+		result.Iterations++
+
+		// Lanczos step.
+		for i := range v {
+			v[i] = y[i] / beta
+		}
+		A.MulVec(y, v)
+		if result.Iterations >= 2 {
+			axpy(-beta/oldb, r1, y)
+		}
+		alpha := dot(v, y)
+		axpy(-alpha/beta, r2, y)
+		r1, r2 = r2, r1
+		copy(r2, y)
+		s.precondition(y, r2)
+		oldb = beta
+		beta = dot(r2, y)
+		if beta < 0 {
+			return result.fail("Preconditioner is not positive definite")
+		}
+		beta = math.Sqrt(beta)
+
+		// Apply the previous rotation and compute the next one.
+		oldeps := epsln
+		delta := cs*dbar + sn*alpha
+		gbar := sn*dbar - cs*alpha
+		epsln = sn * beta
+		dbar = -cs * beta
+		gamma := math.Max(math.Hypot(gbar, beta), epsilon)
+		cs = gbar / gamma
+		sn = beta / gamma
+		phi := cs * phibar
+		phibar = sn * phibar
+
+		// Update the search direction and the solution.
+		w1, w2, w = w2, w, w1
+		for i := range w {
+			w[i] = (v[i] - oldeps*w1[i] - delta*w2[i]) / gamma
+		}
+		axpy(phi, w, x)
+
+		result.History = append(result.History, phibar)
+		if phibar <= tol || beta == 0 {
+			return result.finish(true)
+		}
+	}
+	return result.finish(false)
+}
+
+// GMRES will solve A*x = b for a general square A with the restarted generalised minimal residual method.
+func GMRES(A LinearOperator, b []float64, settings *IterativeSettings) (*IterativeResult, error) {
+	s, x, r, err := startIterative(A, b, settings)
+	if err != nil {
+		return nil, err
+	}
+	result := &IterativeResult{X: x, History: []float64{vectorNorm(r)}}
+	tol := s.threshold(vectorNorm(b))
+	if result.History[0] <= tol {
+		return result.finish(true)
+	}
+
+	// The preconditioner is applied on the right, so the residual in the history is the true residual.
+	n := len(b)
+	m := s.Restart
+	if m > n {
+		m = n
+	}
+	V := make([][]float64, m+1)
+	for i := range V {
+		V[i] = make([]float64, n)
+	}
+	H := make([][]float64, m+1)
+	for i := range H {
+		H[i] = make([]float64, m)
+	}
+	rotations := make([]Givens, m)
+	g := make([]float64, m+1)
+	z := make([]float64, n)
+
+	for {
+		beta := vectorNorm(r)
+		for i := range V[0] {
+			V[0][i] = r[i] / beta
+		}
+		for i := range g {
+			g[i] = 0
+		}
+		g[0] = beta
+
+		k := 0
+		converged := false
+		for k < m && result.Iterations < s.MaxIterations {
+			result.Iterations++
+			s.precondition(z, V[k])
+			w := V[k+1]
+			A.MulVec(w, z)
+
+			// Modified Gram-Schmidt against the previous basis vectors.
+			for i := 0; i <= k; i++ {
+				H[i][k] = dot(w, V[i])
+				axpy(-H[i][k], V[i], w)
+			}
+			H[k+1][k] = vectorNorm(w)
+			if H[k+1][k] != 0 {
+				for i := range w {
+					w[i] /= H[k+1][k]
+				}
+			}
+
+			for i := 0; i < k; i++ {
+				rot := rotations[i]
+				a, c := H[i][k], H[i+1][k]
+				H[i][k] = rot.C*a + rot.S*c
+				H[i+1][k] = -rot.S*a + rot.C*c
+			}
+			breakdown := H[k+1][k] == 0
+			rotations[k], H[k][k] = NewGivens(H[k][k], H[k+1][k], k, k+1)
+			H[k+1][k] = 0
+			g[k+1] = -rotations[k].S * g[k]
+			g[k] = rotations[k].C * g[k]
+			k++
+
+			norm := math.Abs(g[k])
+			result.History = append(result.History, norm)
+			if norm <= tol || breakdown {
+				converged = true
+				break
+			}
+		}
+
+		// Solve the small triangular system and update x with the preconditioned basis.
+		y := make([]float64, k)
+		for i := k - 1; i >= 0; i-- {
+			sum := g[i]
+			for j := i + 1; j < k; j++ {
+				sum -= H[i][j] * y[j]
+			}
+			if H[i][i] == 0 {
+				return result.fail("Iterative solver broke down")
+			}
+			y[i] = sum / H[i][i]
+		}
+		u := make([]float64, n)
+		for j := 0; j < k; j++ {
+			axpy(y[j], V[j], u)
+		}
+		s.precondition(z, u)
+		axpy(1, z, x)
+
+		// Recompute the true residual for the restart, which also guards against drift in the estimate.
+		A.MulVec(r, x)
+		for i := range r {
+			r[i] = b[i] - r[i]
+		}
+		if converged {
+			norm := vectorNorm(r)
+			result.History[len(result.History)-1] = norm
+			if norm <= tol {
+				return result.finish(true)
+			}
+		}
+		if result.Iterations >= s.MaxIterations {
+			return result.finish(false)
+		}
+	}
+}
+
+// BiCGSTAB will solve A*x = b for a general square A with the stabilised biconjugate gradient method of van der Vorst.
+func BiCGSTAB(A LinearOperator, b []float64, settings *IterativeSettings) (*IterativeResult, error) {
+	s, x, r, err := startIterative(A, b, settings)
+	if err != nil {
+		return nil, err
+	}
+	result := &IterativeResult{X: x, History: []float64{vectorNorm(r)}}
+	tol := s.threshold(vectorNorm(b))
+	if result.History[0] <= tol {
+		return result.finish(true)
+	}
+
+	// Two products with A per iteration but, unlike GMRES, only a fixed amount of memory.
+	n := len(b)
+	rhat := append([]float64(nil), r...)
+	p := make([]float64, n)
+	v := make([]float64, n)
+	phat := make([]float64, n)
+	shat := make([]float64, n)
+	t := make([]float64, n)
+	rho, alpha, omega := float64(1), float64(1), float64(1)
+
+	for result.Iterations < s.MaxIterations {
+		result.Iterations++
+		rhoNext := dot(rhat, r)
+		if rhoNext == 0 {
+			return result.fail("Iterative solver broke down")
+		}
+		if result.Iterations == 1 {
+			copy(p, r)
+		} else {
+			beta := (rhoNext / rho) * (alpha / omega)
+			for i := range p {
+				p[i] = r[i] + beta*(p[i]-omega*v[i])
+			}
+		}
+		rho = rhoNext
+
+		s.precondition(phat, p)
+		A.MulVec(v, phat)
+		rhatv := dot(rhat, v)
+		if rhatv == 0 {
+			return result.fail("Iterative solver broke down")
+		}
+		alpha = rho / rhatv
+
+		// r now holds the intermediate residual s = r - alpha*v.
+		axpy(-alpha, v, r)
+		axpy(alpha, phat, x)
+		if norm := vectorNorm(r); norm <= tol {
+			result.History = append(result.History, norm)
+			return result.finish(true)
+		}
+
+		s.precondition(shat, r)
+		A.MulVec(t, shat)
+		tt := dot(t, t)
+		if tt == 0 {
+			return result.fail("Iterative solver broke down")
+		}
+		omega = dot(t, r) / tt
+		axpy(omega, shat, x)
+		axpy(-omega, t, r)
+
+		norm := vectorNorm(r)
+		result.History = append(result.History, norm)
+		if norm <= tol {
+			return result.finish(true)
+		}
+		if omega == 0 {
+			return result.fail("Iterative solver broke down")
+		}
+	}
+	return result.finish(false)
+}
+
+// startIterative checks the dimensions, fills in the default settings and returns the starting x and residual b - A*x.
+func startIterative(A LinearOperator, b []float64, settings *IterativeSettings) (IterativeSettings, []float64, []float64, error) {
+	var s IterativeSettings
+	if settings != nil {
+		s = *settings
+	}
+
+	rows, columns := A.Dims()
+	if rows != columns {
+		return s, nil, nil, errors.New("Not a square matrix")
+	}
+	if len(b) != rows || (s.InitialGuess != nil && len(s.InitialGuess) != rows) {
+		return s, nil, nil, errors.New("matrix dimensions do not agree")
+	}
+
+	if s.Tolerance == 0 {
+		s.Tolerance = 1e-10
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 10 * rows
+	}
+	if s.Restart == 0 {
+		s.Restart = defaultRestart
+	}
+
+	x := make([]float64, rows)
+	r := make([]float64, rows)
+	if s.InitialGuess != nil {
+		copy(x, s.InitialGuess)
+		A.MulVec(r, x)
+	}
+	for i := range r {
+		r[i] = b[i] - r[i]
+	}
+	return s, x, r, nil
+}
+
+// threshold returns the residual norm below which a solve has converged, given the norm of the right hand side.
+func (s IterativeSettings) threshold(normB float64) float64 {
+	return math.Max(s.Tolerance*normB, s.AbsoluteTolerance)
+}
+
+// precondition applies the preconditioner, or copies r when there is none.
+func (s IterativeSettings) precondition(z, r []float64) {
+	if s.Preconditioner == nil {
+		copy(z, r)
+		return
+	}
+	s.Preconditioner.Precondition(z, r)
+}
+
+// finish records the final residual and returns an error if the solver ran out of iterations.
+func (result *IterativeResult) finish(converged bool) (*IterativeResult, error) {
+	result.Converged = converged
+	result.Residual = result.History[len(result.History)-1]
+	if !converged {
+		return result, errors.New("Iterative solver did not converge")
+	}
+	return result, nil
+}
+
+// fail records the final residual and returns the partial result along with the reason for stopping.
+func (result *IterativeResult) fail(reason string) (*IterativeResult, error) {
+	result.Residual = result.History[len(result.History)-1]
+	return result, errors.New(reason)
+}
+
+// dot returns the inner product of two vectors of the same length.
+func dot(x, y []float64) float64 {
+	sum := float64(0)
+	for i, value := range x {
+		sum += value * y[i]
+	}
+	return sum
+}
+
+// axpy replaces y with y + alpha*x.
+func axpy(alpha float64, x, y []float64) {
+	for i, value := range x {
+		y[i] += alpha * value
+	}
+}
+
+// vectorNorm returns the Euclidean norm of a vector.
+func vectorNorm(x []float64) float64 {
+	return math.Sqrt(dot(x, x))
+}
@@ -0,0 +1,252 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// poisson returns the five point Laplacian on a k by k grid, a standard symmetric positive definite test matrix.
+func poisson(k int) *MatrixStruct {
+	n := k * k
+	m, _ := Zeros(n, n)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			row := i*k + j
+			m.Elements[row*n+row] = 4
+			if i > 0 {
+				m.Elements[row*n+row-k] = -1
+			}
+			if i < k-1 {
+				m.Elements[row*n+row+k] = -1
+			}
+			if j > 0 {
+				m.Elements[row*n+row-1] = -1
+			}
+			if j < k-1 {
+				m.Elements[row*n+row+1] = -1
+			}
+		}
+	}
+	return m
+}
+
+// convectionDiffusion returns the Laplacian on a k by k grid with an upwinded convection term, which makes it nonsymmetric.
+func convectionDiffusion(k int) *MatrixStruct {
+	m := poisson(k)
+	n := k * k
+	for row := 0; row < n; row++ {
+		m.Elements[row*n+row] += 0.8
+		if row%k > 0 {
+			m.Elements[row*n+row-1] -= 0.8
+		}
+	}
+	return m
+}
+
+// laplacian1D is a matrix free operator for the tridiagonal matrix with 2 on the diagonal and -1 either side.
+type laplacian1D int
+
+func (l laplacian1D) Dims() (int, int) {
+	return int(l), int(l)
+}
+
+func (l laplacian1D) MulVec(dst, x []float64) {
+	n := int(l)
+	for i := 0; i < n; i++ {
+		dst[i] = 2 * x[i]
+		if i > 0 {
+			dst[i] -= x[i-1]
+		}
+		if i < n-1 {
+			dst[i] -= x[i+1]
+		}
+	}
+}
+
+func sequence(n int) []float64 {
+	b := make([]float64, n)
+	for i := range b {
+		b[i] = float64(i%7) - 3
+	}
+	return b
+}
+
+func assertSolves(assert *assert.Assertions, A *MatrixStruct, b []float64, result *IterativeResult) {
+	expected, err := A.Solve(&MatrixStruct{Rows: len(b), Columns: 1, Capacity: len(b), Elements: b})
+	assert.Nil(err)
+	assert.InDeltaSlice(expected.Elements, result.X, 1e-6)
+	assert.True(result.Converged)
+	assert.Equal(result.Iterations+1, len(result.History))
+	assert.Equal(result.History[len(result.History)-1], result.Residual)
+}
+
+func TestCG(t *testing.T) {
+	assert := assert.New(t)
+
+	A := poisson(8)
+	b := sequence(64)
+
+	plain, err := CG(A, b, nil)
+	assert.Nil(err)
+	assertSolves(assert, A, b, plain)
+
+	ic, err := NewIC0(A)
+	assert.Nil(err)
+	preconditioned, err := CG(A, b, &IterativeSettings{Preconditioner: ic})
+	assert.Nil(err)
+	assertSolves(assert, A, b, preconditioned)
+	assert.True(preconditioned.Iterations < plain.Iterations)
+
+	ssor, err := NewSSOR(A, 1.5)
+	assert.Nil(err)
+	result, err := CG(A, b, &IterativeSettings{Preconditioner: ssor})
+	assert.Nil(err)
+	assertSolves(assert, A, b, result)
+
+	// A matrix free operator and a starting guess.
+	op := laplacian1D(50)
+	x0 := make([]float64, 50)
+	x0[0] = 1
+	result, err = CG(op, sequence(50), &IterativeSettings{InitialGuess: x0})
+	assert.Nil(err)
+	r := make([]float64, 50)
+	op.MulVec(r, result.X)
+	assert.InDeltaSlice(sequence(50), r, 1e-8)
+
+	result, err = CG(A, b, &IterativeSettings{MaxIterations: 2})
+	assert.NotNil(err)
+	assert.False(result.Converged)
+	assert.Equal(2, result.Iterations)
+
+	indefinite, _ := Matrix(2, 2, []float64{1, 0, 0, -1})
+	_, err = CG(indefinite, []float64{1, 1}, nil)
+	assert.NotNil(err)
+
+	_, err = CG(A, []float64{1}, nil)
+	assert.NotNil(err)
+}
+
+func BenchmarkCG(b *testing.B) {
+	A := poisson(10)
+	rhs := sequence(100)
+	for n := 0; n < b.N; n++ {
+		_, _ = CG(A, rhs, nil)
+	}
+}
+
+func TestMINRES(t *testing.T) {
+	assert := assert.New(t)
+
+	A := poisson(6)
+	b := sequence(36)
+	result, err := MINRES(A, b, nil)
+	assert.Nil(err)
+	assertSolves(assert, A, b, result)
+
+	jacobi, err := NewJacobi(A)
+	assert.Nil(err)
+	result, err = MINRES(A, b, &IterativeSettings{Preconditioner: jacobi})
+	assert.Nil(err)
+	assertSolves(assert, A, b, result)
+
+	// Shifting the Laplacian makes it indefinite, which CG cannot handle.
+	shifted := A.Clone()
+	for i := 0; i < 36; i++ {
+		shifted.Elements[i*36+i] -= 3
+	}
+	result, err = MINRES(shifted, b, nil)
+	assert.Nil(err)
+	assertSolves(assert, shifted, b, result)
+
+	_, err = MINRES(A, b, &IterativeSettings{MaxIterations: 1})
+	assert.NotNil(err)
+}
+
+func BenchmarkMINRES(b *testing.B) {
+	A := poisson(10)
+	rhs := sequence(100)
+	for n := 0; n < b.N; n++ {
+		_, _ = MINRES(A, rhs, nil)
+	}
+}
+
+func TestGMRES(t *testing.T) {
+	assert := assert.New(t)
+
+	A := convectionDiffusion(7)
+	b := sequence(49)
+
+	result, err := GMRES(A, b, nil)
+	assert.Nil(err)
+	assertSolves(assert, A, b, result)
+
+	restarted, err := GMRES(A, b, &IterativeSettings{Restart: 5})
+	assert.Nil(err)
+	assertSolves(assert, A, b, restarted)
+
+	ilu, err := NewILU0(A)
+	assert.Nil(err)
+	preconditioned, err := GMRES(A, b, &IterativeSettings{Preconditioner: ilu})
+	assert.Nil(err)
+	assertSolves(assert, A, b, preconditioned)
+	assert.True(preconditioned.Iterations < result.Iterations)
+
+	// A small system converges exactly through the lucky breakdown.
+	small, _ := Matrix(2, 2, []float64{2, 1, 0, 3})
+	result, err = GMRES(small, []float64{3, 3}, nil)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1, 1}, result.X, 1e-12)
+
+	_, err = GMRES(A, b, &IterativeSettings{MaxIterations: 3})
+	assert.NotNil(err)
+}
+
+func BenchmarkGMRES(b *testing.B) {
+	A := convectionDiffusion(10)
+	rhs := sequence(100)
+	for n := 0; n < b.N; n++ {
+		_, _ = GMRES(A, rhs, nil)
+	}
+}
+
+func TestBiCGSTAB(t *testing.T) {
+	assert := assert.New(t)
+
+	A := convectionDiffusion(7)
+	b := sequence(49)
+
+	result, err := BiCGSTAB(A, b, nil)
+	assert.Nil(err)
+	assertSolves(assert, A, b, result)
+
+	ilu, err := NewILU0(A)
+	assert.Nil(err)
+	preconditioned, err := BiCGSTAB(A, b, &IterativeSettings{Preconditioner: ilu})
+	assert.Nil(err)
+	assertSolves(assert, A, b, preconditioned)
+	assert.True(preconditioned.Iterations < result.Iterations)
+
+	zero := make([]float64, 49)
+	result, err = BiCGSTAB(A, zero, nil)
+	assert.Nil(err)
+	assert.Equal(0, result.Iterations)
+	assert.Equal(zero, result.X)
+
+	_, err = BiCGSTAB(A, b, &IterativeSettings{MaxIterations: 1})
+	assert.NotNil(err)
+
+	// For a rotation A*r is orthogonal to r, so the first step divides by zero unless the breakdown is caught.
+	rotation, _ := Matrix(2, 2, []float64{0, 1, -1, 0})
+	result, err = BiCGSTAB(rotation, []float64{1, 0}, nil)
+	assert.EqualError(err, "Iterative solver broke down")
+	assert.Equal(1, result.Iterations)
+	assert.Equal([]float64{0, 0}, result.X)
+}
+
+func BenchmarkBiCGSTAB(b *testing.B) {
+	A := convectionDiffusion(10)
+	rhs := sequence(100)
+	for n := 0; n < b.N; n++ {
+		_, _ = BiCGSTAB(A, rhs, nil)
+	}
+}
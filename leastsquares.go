@@ -0,0 +1,102 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// LeastSquares will return the matrix x that minimises the Frobenius norm of m*x - b, along with that residual norm.
+func (m MatrixStruct) LeastSquares(b *MatrixStruct) (x *MatrixStruct, residual float64, err error) {
+	if b.Rows != m.Rows {
+		return nil, 0, errors.New("matrix dimensions do not agree")
+	}
+
+	// Tall matrices with full column rank are solved with QR, anything else gets the minimum norm solution from the SVD.
+	if m.Rows >= m.Columns {
+		f := m.QRCompact()
+		R := f.R(false)
+		if R.hasFullDiagonal() {
+			c, _ := f.ApplyQT(b)
+			x, _ = Zeros(m.Columns, b.Columns)
+			copy(x.Elements, c.Elements[:m.Columns*b.Columns])
+			if err := backSubstitution(R, x, false); err != nil {
+				return nil, 0, err
+			}
+
+			for _, value := range c.Elements[m.Columns*b.Columns:] {
+				residual = math.Hypot(residual, value)
+			}
+			return x, residual, nil
+		}
+	}
+
+	pinv, err := m.pseudoInverseSVD()
+	if err != nil {
+		return nil, 0, err
+	}
+	x, _ = pinv.Multiply(b)
+	ax, _ := m.Multiply(x)
+	r, _ := ax.Subtract(b)
+	return x, r.frobenius(), nil
+}
+
+// PseudoInverse will return the Moore-Penrose pseudoinverse of the matrix.
+func (m MatrixStruct) PseudoInverse() (*MatrixStruct, error) {
+	// Singular values below the default tolerance are treated as zero when QR cannot be used.
+	if m.Rows >= m.Columns {
+		f := m.QRCompact()
+		R := f.R(false)
+		if R.hasFullDiagonal() {
+			pinv, _ := Zeros(m.Columns, m.Rows)
+			QT := f.Q(false).Transpose()
+			copy(pinv.Elements, QT.Elements[:m.Columns*m.Rows])
+			if err := backSubstitution(R, pinv, false); err != nil {
+				return nil, err
+			}
+			return pinv, nil
+		}
+	}
+
+	return m.pseudoInverseSVD()
+}
+
+// pseudoInverseSVD computes V*Sigma^+*U^T, inverting only the singular values above the default tolerance.
+func (m MatrixStruct) pseudoInverseSVD() (*MatrixStruct, error) {
+	u, s, v, err := m.svd(true)
+	if err != nil {
+		return nil, err
+	}
+	tol := rankTolerance(m.Rows, m.Columns, s)
+
+	k := len(s)
+	for j := 0; j < k; j++ {
+		scale := float64(0)
+		if s[j] > tol {
+			scale = 1 / s[j]
+		}
+		for i := 0; i < v.Rows; i++ {
+			v.Elements[i*k+j] *= scale
+		}
+	}
+
+	pinv, _ := v.Multiply(u.Transpose())
+	return pinv, nil
+}
+
+// hasFullDiagonal reports whether every diagonal element of the upper triangular factor is large enough to safely back substitute.
+func (m MatrixStruct) hasFullDiagonal() bool {
+	k := m.shortestDimension()
+	max := float64(0)
+	for i := 0; i < k; i++ {
+		max = math.Max(max, math.Abs(m.Elements[i*m.Columns+i]))
+	}
+
+	// Without pivoting the diagonal only hints at the rank, so anything close to singular is left to the SVD.
+	tol := math.Sqrt(epsilon) * max
+	for i := 0; i < k; i++ {
+		if !(math.Abs(m.Elements[i*m.Columns+i]) > tol) {
+			return false
+		}
+	}
+	return true
+}
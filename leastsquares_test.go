@@ -0,0 +1,95 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestLeastSquares(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 2, []float64{1, 1, 1, 2, 1, 3, 1, 4})
+	assert.Nil(err)
+	b, err := Vector(6, 5, 7, 10)
+	assert.Nil(err)
+
+	x, residual, err := a.LeastSquares(b)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{3.5, 1.4}, x.Elements, 1e-12)
+	assert.InDelta(math.Sqrt(4.2), residual, 1e-12)
+
+	c, err := Matrix(3, 2, []float64{1, 2, 2, 4, 3, 6})
+	assert.Nil(err)
+	d, err := Vector(1, 2, 3)
+	assert.Nil(err)
+
+	x, residual, err = c.LeastSquares(d)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0.2, 0.4}, x.Elements, 1e-12)
+	assert.InDelta(0, residual, 1e-12)
+
+	e, err := Matrix(2, 3, []float64{1, 0, 1, 0, 1, 1})
+	assert.Nil(err)
+	f, err := Vector(2, 2)
+	assert.Nil(err)
+
+	x, residual, err = e.LeastSquares(f)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{2.0 / 3, 2.0 / 3, 4.0 / 3}, x.Elements, 1e-12)
+	assert.InDelta(0, residual, 1e-12)
+
+	_, _, err = a.LeastSquares(f)
+	assert.NotNil(err)
+}
+
+func BenchmarkLeastSquares(b *testing.B) {
+	a, _ := Matrix(4, 2, []float64{1, 1, 1, 2, 1, 3, 1, 4})
+	v, _ := Vector(6, 5, 7, 10)
+	for n := 0; n < b.N; n++ {
+		_, _, _ = a.LeastSquares(v)
+	}
+}
+
+func TestPseudoInverse(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+
+	pinv, err := a.PseudoInverse()
+	assert.Nil(err)
+	assert.Equal(pinv.Rows, 2)
+	assert.Equal(pinv.Columns, 3)
+	assert.InDeltaSlice([]float64{-4.0 / 3, -1.0 / 3, 2.0 / 3, 13.0 / 12, 1.0 / 3, -5.0 / 12}, pinv.Elements, 1e-12)
+
+	b, err := Matrix(2, 2, []float64{1, 2, 2, 4})
+	assert.Nil(err)
+
+	pinv, err = b.PseudoInverse()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0.04, 0.08, 0.08, 0.16}, pinv.Elements, 1e-12)
+
+	c := a.Transpose()
+	pinv, err = c.PseudoInverse()
+	assert.Nil(err)
+	cp, _ := c.Multiply(pinv)
+	eye, _ := Eye(2, 2)
+	assert.InDeltaSlice(eye.Elements, cp.Elements, 1e-12)
+}
+
+func TestLeastSquaresNotConverged(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 2, []float64{1, math.NaN(), 3, 4, 5, 6})
+	assert.Nil(err)
+	b, err := Vector(1, 2, 3)
+	assert.Nil(err)
+
+	x, _, err := a.LeastSquares(b)
+	assert.Nil(x)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+	pinv, err := a.PseudoInverse()
+	assert.Nil(pinv)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+}
@@ -0,0 +1,196 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// luFactors stores an LU decomposition in compact form, with L below the diagonal and U on and above it.
+type luFactors struct {
+	lu       *MatrixStruct
+	pivot    []int // row i of lu came from row pivot[i] of the original matrix
+	sign     float64
+	singular bool
+}
+
+// luDecompose computes the row pivoted LU decomposition of a square matrix, marking it singular when a pivot is negligible.
+func (m MatrixStruct) luDecompose() luFactors {
+	n := m.Rows
+	lu := m.Clone()
+	a := lu.Elements
+	pivot := make([]int, n)
+	for i := range pivot {
+		pivot[i] = i
+	}
+	sign := float64(1)
+	singular := false
+	// Pivots this small are treated as zero rather than producing Inf or NaN values.
+	tol := float64(n) * epsilon * m.maxAbs()
+
+	for k := 0; k < n; k++ {
+		p := k
+		max := math.Abs(a[k*n+k])
+		for i := k + 1; i < n; i++ {
+			if v := math.Abs(a[i*n+k]); v > max {
+				max = v
+				p = i
+			}
+		}
+
+		if p != k {
+			for j := 0; j < n; j++ {
+				a[p*n+j], a[k*n+j] = a[k*n+j], a[p*n+j]
+			}
+			pivot[p], pivot[k] = pivot[k], pivot[p]
+			sign = -sign
+		}
+
+		if max <= tol {
+			singular = true
+			if max == 0 {
+				continue
+			}
+		}
+
+		for i := k + 1; i < n; i++ {
+			a[i*n+k] /= a[k*n+k]
+			l := a[i*n+k]
+			if l == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= l * a[k*n+j]
+			}
+		}
+	}
+
+	return luFactors{lu: lu, pivot: pivot, sign: sign, singular: singular}
+}
+
+// solve returns the solution x of A*x = b using the stored factors, leaving b untouched.
+func (f luFactors) solve(b *MatrixStruct) (*MatrixStruct, error) {
+	n := f.lu.Rows
+	x, _ := Zeros(n, b.Columns)
+	for i, p := range f.pivot {
+		copy(x.Elements[i*b.Columns:(i+1)*b.Columns], b.Elements[p*b.Columns:(p+1)*b.Columns])
+	}
+
+	if err := forwardSubstitution(f.lu, x, true); err != nil {
+		return nil, err
+	}
+	if err := backSubstitution(f.lu, x, false); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// solveTranspose returns the solution x of A^T*x = b using the stored factors.
+func (f luFactors) solveTranspose(b *MatrixStruct) (*MatrixStruct, error) {
+	n := f.lu.Rows
+	// P*A = L*U, so this solves U^T*L^T*y = b and undoes the permutation. The transposed factors hold U^T in the lower triangle and L^T above it.
+	UT := f.lu.Transpose()
+	y := b.Clone()
+
+	if err := forwardSubstitution(UT, y, false); err != nil {
+		return nil, err
+	}
+	if err := backSubstitution(UT, y, true); err != nil {
+		return nil, err
+	}
+
+	x, _ := Zeros(n, b.Columns)
+	for i, p := range f.pivot {
+		copy(x.Elements[p*b.Columns:(p+1)*b.Columns], y.Elements[i*b.Columns:(i+1)*b.Columns])
+	}
+	return x, nil
+}
+
+// LU will return the matrices L, U and P that satisfy P*A = L*U, or an error if the matrix is singular to working precision.
+func (m MatrixStruct) LU() (L, U, P *MatrixStruct, err error) {
+	if !m.IsSquare() {
+		return nil, nil, nil, errors.New("Not a square matrix")
+	}
+
+	f := m.luDecompose()
+	if f.singular {
+		return nil, nil, nil, errors.New("Matrix is singular to working precision")
+	}
+
+	n := m.Rows
+	L, _ = Eye(n, n)
+	U, _ = Zeros(n, n)
+	P, _ = Zeros(n, n)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if j < i {
+				L.Elements[i*n+j] = f.lu.Elements[i*n+j]
+			} else {
+				U.Elements[i*n+j] = f.lu.Elements[i*n+j]
+			}
+		}
+		P.Elements[i*n+f.pivot[i]] = 1
+	}
+
+	return
+}
+
+// Solve will return the matrix x that satisfies m*x = b using the LU decomposition, or an error if the matrix is singular or ill-conditioned.
+func (m MatrixStruct) Solve(b *MatrixStruct) (*MatrixStruct, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+
+	if b.Rows != m.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	f := m.luDecompose()
+	if f.singular {
+		return nil, errors.New("Matrix is singular to working precision")
+	}
+
+	invNorm, err := estimateInverseNorm1(m.Rows, f.solve, f.solveTranspose)
+	if err != nil || isIllConditioned(m.norm1()*invNorm) {
+		return nil, errors.New("Matrix is singular or ill-conditioned")
+	}
+
+	return f.solve(b)
+}
+
+// Det will return the determinant of a square matrix as the product of the pivots of its LU decomposition.
+func (m MatrixStruct) Det() (float64, error) {
+	if !m.IsSquare() {
+		return 0, errors.New("Not a square matrix")
+	}
+
+	f := m.luDecompose()
+	det := f.sign
+	for i := 0; i < m.Rows; i++ {
+		det *= f.lu.Elements[i*m.Columns+i]
+	}
+	return det, nil
+}
+
+// LogDet will return the logarithm of the absolute value of the determinant and its sign, which avoids the overflow of Det on large matrices.
+func (m MatrixStruct) LogDet() (logAbs, sign float64, err error) {
+	if !m.IsSquare() {
+		return 0, 0, errors.New("Not a square matrix")
+	}
+
+	f := m.luDecompose()
+	sign = f.sign
+	for i := 0; i < m.Rows; i++ {
+		pivot := f.lu.Elements[i*m.Columns+i]
+		// A singular matrix has a sign of 0 and a log determinant of -Inf.
+		if pivot == 0 {
+			return math.Inf(-1), 0, nil
+		}
+		if pivot < 0 {
+			sign = -sign
+		}
+		logAbs += math.Log(math.Abs(pivot))
+	}
+
+	return logAbs, sign, nil
+}
@@ -0,0 +1,159 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestLU(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	assert.Nil(err)
+
+	L, U, P, err := a.LU()
+	assert.Nil(err)
+	assert.True(L.IsLowerTriangular())
+	assert.True(U.IsUpperTriangular())
+
+	for i := 0; i < 4; i++ {
+		assert.Equal(L.Elements[i*4+i], float64(1))
+	}
+
+	PA, err := P.Multiply(a)
+	assert.Nil(err)
+	LU, err := L.Multiply(U)
+	assert.Nil(err)
+	assert.InDeltaSlice(PA.Elements, LU.Elements, 1e-12)
+
+	b, err := Matrix(3, 3, []float64{1, 2, 3, 2, 4, 6, 1, 1, 1})
+	assert.Nil(err)
+	_, _, _, err = b.LU()
+	assert.NotNil(err)
+
+	c, err := Matrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	_, _, _, err = c.LU()
+	assert.NotNil(err)
+}
+
+func BenchmarkLU(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	for n := 0; n < b.N; n++ {
+		_, _, _, _ = a.LU()
+	}
+}
+
+func TestSolve(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{0, 2, 1, 1, 1, 1, 4, -1, 3})
+	assert.Nil(err)
+
+	b, err := Matrix(3, 2, []float64{5, 1, 4, 2, 5, 5})
+	assert.Nil(err)
+
+	x, err := a.Solve(b)
+	assert.Nil(err)
+	assert.Equal(x.Rows, 3)
+	assert.Equal(x.Columns, 2)
+
+	ax, err := a.Multiply(x)
+	assert.Nil(err)
+	assert.InDeltaSlice(b.Elements, ax.Elements, 1e-12)
+	assert.InDeltaSlice([]float64{1, 2, 1}, []float64{x.Elements[0], x.Elements[2], x.Elements[4]}, 1e-12)
+
+	s, err := Matrix(2, 2, []float64{1, 2, 2, 4})
+	assert.Nil(err)
+	v, err := Vector(1, 2)
+	assert.Nil(err)
+	y, err := s.Solve(v)
+	assert.Nil(y)
+	assert.NotNil(err)
+
+	h, err := Matrix(3, 3, []float64{1, 1, 1, 1, 1 + 1e-15, 1, 1, 1, 1 + 2e-15})
+	assert.Nil(err)
+	u, err := Vector(1, 2, 3)
+	assert.Nil(err)
+	y, err = h.Solve(u)
+	assert.Nil(y)
+	assert.NotNil(err)
+
+	w, err := Vector(1, 2, 3, 4)
+	assert.Nil(err)
+	z, err := a.Solve(w)
+	assert.Nil(z)
+	assert.NotNil(err)
+}
+
+func BenchmarkSolve(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	v, _ := Vector(1, 2, 3, 4)
+	for n := 0; n < b.N; n++ {
+		_, _ = a.Solve(v)
+	}
+}
+
+func TestDet(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{0, 2, 1, 1, 1, 1, 4, -1, 3})
+	assert.Nil(err)
+	det, err := a.Det()
+	assert.Nil(err)
+	assert.InDelta(-3, det, 1e-12)
+
+	b, err := Matrix(2, 2, []float64{1, 2, 2, 4})
+	assert.Nil(err)
+	det, err = b.Det()
+	assert.Nil(err)
+	assert.Equal(float64(0), det)
+
+	c, err := Matrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	_, err = c.Det()
+	assert.NotNil(err)
+}
+
+func BenchmarkDet(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	for n := 0; n < b.N; n++ {
+		_, _ = a.Det()
+	}
+}
+
+func TestLogDet(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{0, 2, 1, 1, 1, 1, 4, -1, 3})
+	assert.Nil(err)
+	logAbs, sign, err := a.LogDet()
+	assert.Nil(err)
+	assert.InDelta(math.Log(3), logAbs, 1e-12)
+	assert.Equal(float64(-1), sign)
+
+	b, err := Eye(400, 400)
+	assert.Nil(err)
+	b = b.ScalarMultiply(10)
+	logAbs, sign, err = b.LogDet()
+	assert.Nil(err)
+	assert.InDelta(400*math.Log(10), logAbs, 1e-9)
+	assert.Equal(float64(1), sign)
+
+	det, err := b.Det()
+	assert.Nil(err)
+	assert.True(math.IsInf(det, 1))
+
+	c, err := Matrix(2, 2, []float64{1, 2, 2, 4})
+	assert.Nil(err)
+	logAbs, sign, err = c.LogDet()
+	assert.Nil(err)
+	assert.True(math.IsInf(logAbs, -1))
+	assert.Equal(float64(0), sign)
+
+	d, err := Matrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	_, _, err = d.LogDet()
+	assert.NotNil(err)
+}
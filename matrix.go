@@ -13,10 +13,25 @@ type MatrixStruct struct {
 	Elements                []float64
 }
 
+// epsilon is the machine epsilon for float64 values, the distance from 1 to the next representable number.
+const epsilon = 2.220446049250313e-16
+
 func (m MatrixStruct) shortestDimension() int {
 	return int(math.Min(float64(m.Rows), float64(m.Columns)))
 }
 
+func (m MatrixStruct) longestDimension() int {
+	return int(math.Max(float64(m.Rows), float64(m.Columns)))
+}
+
+func (m MatrixStruct) maxAbs() float64 {
+	max := float64(0)
+	for _, elem := range m.Elements {
+		max = math.Max(max, math.Abs(elem))
+	}
+	return max
+}
+
 // Matrix will return a MatrixStruct structure containing the Elements given in the list. This function will also parse the Elements and check for input errors.
 func Matrix(rows int, columns int, elements []float64) (*MatrixStruct, error) {
 	if rows < 1 || columns < 1 {
@@ -163,18 +178,6 @@ func (m MatrixStruct) ScalarMultiply(s float64) *MatrixStruct {
 	return matrix
 }
 
-func (m MatrixStruct) norm2() (norm float64) {
-	norm = 0
-	for i := 0; i < m.Columns; i++ {
-		sum := float64(0)
-		for j := 0; j < m.Rows; j++ {
-			sum += math.Pow(m.Elements[j*m.Columns+i], float64(2))
-		}
-		norm = math.Max(norm, sum)
-	}
-	return math.Sqrt(norm)
-}
-
 // Clone returns a new matrix that is an exact copy of the selected matrix.
 func (m MatrixStruct) Clone() *MatrixStruct {
 	s := make([]float64, len(m.Elements))
@@ -183,20 +186,6 @@ func (m MatrixStruct) Clone() *MatrixStruct {
 	return matrix
 }
 
-// Normal returns the selected matrix normal.
-func (m MatrixStruct) Normal(normType string) float64 {
-	switch normType {
-	case ("2"):
-		{
-			return m.norm2()
-		}
-	default:
-		{
-			return m.norm2()
-		}
-	}
-}
-
 // Minor will return a new matrix whos values are the minor of the selected matrix at the given coordinates.
 func (m MatrixStruct) Minor(column1, column2, row1, row2 int) (*MatrixStruct, error) {
 	if row1 > row2 || column1 > column2 {
@@ -296,13 +285,13 @@ func (m MatrixStruct) QR() (Q *MatrixStruct, R *MatrixStruct) {
 		xTop, _ := x.GetValue(0, 0)
 
 		if xTop < 0 {
-			y.SetValue(0, 0, x.Normal("2"))
+			y.SetValue(0, 0, x.frobenius())
 		} else {
-			y.SetValue(0, 0, -x.Normal("2"))
+			y.SetValue(0, 0, -x.frobenius())
 		}
 
 		v, _ := x.Subtract(y)
-		u := v.ScalarMultiply(1 / v.Normal("2"))
+		u := v.ScalarMultiply(1 / v.frobenius())
 
 		r1, _ := R.Minor(k, M-1, k, N-1)
 		uDot, _ := u.Multiply(u.Transpose())
@@ -328,6 +317,24 @@ func (m MatrixStruct) IsSquare() bool {
 	return false
 }
 
+// IsSymmetric will return true if the matrix is square and equal to its transpose.
+func (m MatrixStruct) IsSymmetric() bool {
+	if !m.IsSquare() {
+		return false
+	}
+
+	// Round off from earlier computations should not break the symmetry.
+	tol := float64(m.Rows) * epsilon * m.maxAbs()
+	for i := 0; i < m.Rows; i++ {
+		for j := 0; j < i; j++ {
+			if math.Abs(m.Elements[i*m.Columns+j]-m.Elements[j*m.Columns+i]) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // Prune will zero out small elements in a matrix to allow faster computations. Tolerance in set at 1e-10.
 func (m MatrixStruct) Prune() (*MatrixStruct, error) {
 	elements := make([]float64, m.Capacity)
@@ -343,7 +350,7 @@ func (m MatrixStruct) Prune() (*MatrixStruct, error) {
 	return Matrix(m.Rows, m.Columns, elements)
 }
 
-// Inverse will compute the inverse matrix of a given matrix. FYI at the moment there is no check to see if the matrix is invertable.
+// Inverse will compute the inverse matrix of a given matrix using the QR decomposition, or return an error if it is singular or ill-conditioned.
 func (m MatrixStruct) Inverse() (*MatrixStruct, error) {
 	if !m.IsSquare() {
 		return nil, errors.New("Not a square matrix")
@@ -351,8 +358,30 @@ func (m MatrixStruct) Inverse() (*MatrixStruct, error) {
 
 	Q, R := m.QR()
 	Q_t := Q.Transpose()
-	R, _ = R.Prune()
-	R_inv, _ := R.TriangleInverse()
-	inv, _ := R_inv.Multiply(Q_t)
+	R_t := R.Transpose()
+
+	solve := func(b *MatrixStruct) (*MatrixStruct, error) {
+		x, _ := Q_t.Multiply(b)
+		return x, backSubstitution(R, x, false)
+	}
+	solveTranspose := func(b *MatrixStruct) (*MatrixStruct, error) {
+		y := b.Clone()
+		if err := forwardSubstitution(R_t, y, false); err != nil {
+			return nil, err
+		}
+		return Q.Multiply(y)
+	}
+
+	invNorm, err := estimateInverseNorm1(m.Rows, solve, solveTranspose)
+	if err != nil || isIllConditioned(m.norm1()*invNorm) {
+		return nil, errors.New("Matrix is singular or ill-conditioned")
+	}
+
+	// Only the upper triangle of R is read, so rounding left below the diagonal by QR does not need pruning.
+	R_inv, err := R.packUpper().Inverse()
+	if err != nil {
+		return nil, err
+	}
+	inv, _ := R_inv.Dense().Multiply(Q_t)
 	return inv, nil
 }
@@ -405,6 +405,19 @@ func TestInverse(t *testing.T) {
 	b_inv, err := b.Inverse()
 	assert.NotNil(err)
 	assert.Nil(b_inv)
+
+	c, err := Matrix(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	assert.Nil(err)
+	c_inv, err := c.Inverse()
+	assert.NotNil(err)
+	assert.Nil(c_inv)
+
+	// A well conditioned matrix is inverted whatever its scale.
+	d, err := Matrix(2, 2, []float64{1e-12, 0, 0, 1e-12})
+	assert.Nil(err)
+	d_inv, err := d.Inverse()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1e12, 0, 0, 1e12}, d_inv.Elements, 1e-3)
 }
 
 func BenchmarkInverse(b *testing.B) {
@@ -432,3 +445,19 @@ func BenchmarkPrune(b *testing.B) {
 		_, _ = a.Prune()
 	}
 }
+
+func TestIsSymmetric(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{1, 2, 3, 2, 4, 5, 3, 5, 6})
+	assert.Nil(err)
+	assert.True(a.IsSymmetric())
+
+	b, err := Matrix(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	assert.Nil(err)
+	assert.False(b.IsSymmetric())
+
+	c, err := Matrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	assert.False(c.IsSymmetric())
+}
@@ -0,0 +1,91 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// NormType selects which matrix norm is computed by Normal.
+type NormType int
+
+const (
+	// Norm1 is the maximum absolute column sum.
+	Norm1 NormType = iota
+	// Norm2 is the spectral norm, the largest singular value.
+	Norm2
+	// NormInf is the maximum absolute row sum.
+	NormInf
+	// NormFrobenius is the square root of the sum of the squares of all elements.
+	NormFrobenius
+	// NormMax is the largest absolute element.
+	NormMax
+	// NormNuclear is the sum of the singular values.
+	NormNuclear
+)
+
+// Normal will return the selected matrix norm, or an error for an unknown norm type.
+func (m MatrixStruct) Normal(normType NormType) (float64, error) {
+	switch normType {
+	case Norm1:
+		return m.norm1(), nil
+	case Norm2:
+		if m.IsVector() {
+			return m.frobenius(), nil
+		}
+		s, err := m.SingularValues()
+		if err != nil {
+			return 0, err
+		}
+		return s[0], nil
+	case NormInf:
+		return m.normInf(), nil
+	case NormFrobenius:
+		return m.frobenius(), nil
+	case NormMax:
+		return m.maxAbs(), nil
+	case NormNuclear:
+		s, err := m.SingularValues()
+		if err != nil {
+			return 0, err
+		}
+		sum := float64(0)
+		for _, value := range s {
+			sum += value
+		}
+		return sum, nil
+	default:
+		return 0, errors.New("Unknown norm type")
+	}
+}
+
+func (m MatrixStruct) norm1() float64 {
+	norm := float64(0)
+	for j := 0; j < m.Columns; j++ {
+		sum := float64(0)
+		for i := 0; i < m.Rows; i++ {
+			sum += math.Abs(m.Elements[i*m.Columns+j])
+		}
+		norm = math.Max(norm, sum)
+	}
+	return norm
+}
+
+func (m MatrixStruct) normInf() float64 {
+	norm := float64(0)
+	for i := 0; i < m.Rows; i++ {
+		sum := float64(0)
+		for j := 0; j < m.Columns; j++ {
+			sum += math.Abs(m.Elements[i*m.Columns+j])
+		}
+		norm = math.Max(norm, sum)
+	}
+	return norm
+}
+
+func (m MatrixStruct) frobenius() float64 {
+	norm := float64(0)
+	for _, elem := range m.Elements {
+		norm = math.Hypot(norm, elem)
+	}
+	return norm
+}
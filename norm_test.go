@@ -0,0 +1,64 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestNormal(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(2, 2, []float64{1, -2, 3, 4})
+	assert.Nil(err)
+
+	norm, err := a.Normal(Norm1)
+	assert.Nil(err)
+	assert.Equal(float64(6), norm)
+
+	norm, err = a.Normal(NormInf)
+	assert.Nil(err)
+	assert.Equal(float64(7), norm)
+
+	norm, err = a.Normal(NormFrobenius)
+	assert.Nil(err)
+	assert.InDelta(math.Sqrt(30), norm, 1e-12)
+
+	norm, err = a.Normal(NormMax)
+	assert.Nil(err)
+	assert.Equal(float64(4), norm)
+
+	b, err := Matrix(2, 3, []float64{3, 2, 2, 2, 3, -2})
+	assert.Nil(err)
+
+	norm, err = b.Normal(Norm2)
+	assert.Nil(err)
+	assert.InDelta(5, norm, 1e-12)
+
+	norm, err = b.Normal(NormNuclear)
+	assert.Nil(err)
+	assert.InDelta(8, norm, 1e-12)
+
+	c, err := Vector(3, 4)
+	assert.Nil(err)
+	norm, err = c.Normal(Norm2)
+	assert.Nil(err)
+	assert.Equal(float64(5), norm)
+
+	_, err = a.Normal(NormType(42))
+	assert.NotNil(err)
+
+	d, err := Matrix(2, 2, []float64{1, math.NaN(), 3, 4})
+	assert.Nil(err)
+	_, err = d.Normal(Norm2)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+	_, err = d.Normal(NormNuclear)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+}
+
+func BenchmarkNormal(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	for n := 0; n < b.N; n++ {
+		_, _ = a.Normal(Norm2)
+	}
+}
@@ -0,0 +1,250 @@
+package matrix
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+)
+
+// Ordering selects the fill reducing permutation used by the sparse factorizations.
+type Ordering int
+
+const (
+	// OrderingNatural keeps the rows and columns in their original order.
+	OrderingNatural Ordering = iota
+	// OrderingRCM is the reverse Cuthill-McKee ordering, which reduces the bandwidth and profile of the matrix.
+	OrderingRCM
+	// OrderingMinimumDegree is the minimum degree ordering, which usually gives much less fill than RCM.
+	OrderingMinimumDegree
+)
+
+// Order will return a fill reducing permutation of the square matrix, where perm[k] is the original index of the row and column placed at position k.
+func (a *CSR) Order(ordering Ordering) ([]int, error) {
+	if a.Rows != a.Columns {
+		return nil, errors.New("Not a square matrix")
+	}
+
+	// The ordering is computed on the pattern of A + A^T, so only the positions of the stored elements matter.
+	switch ordering {
+	case OrderingNatural:
+		return identity(a.Rows), nil
+	case OrderingRCM:
+		return reverseCuthillMcKee(a.adjacency()), nil
+	case OrderingMinimumDegree:
+		return minimumDegree(a.adjacency()), nil
+	}
+	return nil, errors.New("Unknown ordering")
+}
+
+// adjacency returns the sorted neighbours of every node in the graph of A + A^T, leaving out the diagonal.
+func (a *CSR) adjacency() [][]int {
+	sum, _ := a.Add(a.Transpose())
+	adj := make([][]int, a.Rows)
+	for i := 0; i < a.Rows; i++ {
+		for p := sum.RowPointers[i]; p < sum.RowPointers[i+1]; p++ {
+			if j := sum.ColumnIndices[p]; j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+	return adj
+}
+
+// reverseCuthillMcKee returns the reverse Cuthill-McKee ordering of every connected component of the graph.
+func reverseCuthillMcKee(adj [][]int) []int {
+	n := len(adj)
+	visited := make([]bool, n)
+	level := make([]int, n)
+	for i := range level {
+		level[i] = -1
+	}
+	order := make([]int, 0, n)
+
+	for s := 0; s < n; s++ {
+		if visited[s] {
+			continue
+		}
+
+		// Breadth first search from a pseudo-peripheral node, visiting neighbours in order of increasing degree.
+		start := pseudoPeripheral(adj, s, visited, level)
+		queue := []int{start}
+		visited[start] = true
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			order = append(order, v)
+
+			var next []int
+			for _, u := range adj[v] {
+				if !visited[u] {
+					visited[u] = true
+					next = append(next, u)
+				}
+			}
+			sort.SliceStable(next, func(i, j int) bool { return len(adj[next[i]]) < len(adj[next[j]]) })
+			queue = append(queue, next...)
+		}
+	}
+
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+// pseudoPeripheral finds a node of nearly maximal eccentricity in the component of s with the algorithm of George and Liu.
+func pseudoPeripheral(adj [][]int, s int, visited []bool, level []int) int {
+	root := s
+	eccentricity := -1
+	for {
+		last, depth := bfsLevels(adj, root, visited, level)
+		if depth <= eccentricity {
+			return root
+		}
+		eccentricity = depth
+
+		// Move to the lowest degree node in the last level while the eccentricity grows.
+		next := last[0]
+		for _, v := range last {
+			if len(adj[v]) < len(adj[next]) {
+				next = v
+			}
+		}
+		if next == root {
+			return root
+		}
+		root = next
+	}
+}
+
+// bfsLevels runs a breadth first search from root over the unvisited nodes, returning the nodes in the last level and the depth of the search.
+func bfsLevels(adj [][]int, root int, visited []bool, level []int) ([]int, int) {
+	var seen []int
+	level[root] = 0
+	seen = append(seen, root)
+	for head := 0; head < len(seen); head++ {
+		v := seen[head]
+		for _, u := range adj[v] {
+			if !visited[u] && level[u] < 0 {
+				level[u] = level[v] + 1
+				seen = append(seen, u)
+			}
+		}
+	}
+
+	// Levels are reset before returning so the caller can search again.
+	depth := level[seen[len(seen)-1]]
+	var last []int
+	for _, v := range seen {
+		if level[v] == depth {
+			last = append(last, v)
+		}
+	}
+	for _, v := range seen {
+		level[v] = -1
+	}
+	return last, depth
+}
+
+// degreeHeap is a priority queue of nodes keyed by degree, with ties broken by the node index so that orderings are deterministic.
+type degreeHeap [][2]int
+
+func (h degreeHeap) Len() int { return len(h) }
+func (h degreeHeap) Less(i, j int) bool {
+	return h[i][0] < h[j][0] || (h[i][0] == h[j][0] && h[i][1] < h[j][1])
+}
+func (h degreeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *degreeHeap) Push(x interface{}) { *h = append(*h, x.([2]int)) }
+func (h *degreeHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// minimumDegree returns the minimum degree ordering of the graph by simulating elimination on it.
+func minimumDegree(adj [][]int) []int {
+	n := len(adj)
+	graph := make([]map[int]struct{}, n)
+	h := make(degreeHeap, 0, n)
+	for i := range adj {
+		graph[i] = make(map[int]struct{}, len(adj[i]))
+		for _, j := range adj[i] {
+			graph[i][j] = struct{}{}
+		}
+		h = append(h, [2]int{len(adj[i]), i})
+	}
+	heap.Init(&h)
+
+	eliminated := make([]bool, n)
+	order := make([]int, 0, n)
+	for len(order) < n {
+		entry := heap.Pop(&h).([2]int)
+		v := entry[1]
+		// Stale heap entries are skipped rather than updated in place.
+		if eliminated[v] || entry[0] != len(graph[v]) {
+			continue
+		}
+		eliminated[v] = true
+		order = append(order, v)
+
+		neighbours := make([]int, 0, len(graph[v]))
+		for u := range graph[v] {
+			neighbours = append(neighbours, u)
+		}
+		sort.Ints(neighbours)
+
+		// Eliminating v joins its neighbours into a clique, which is the fill it causes.
+		for _, u := range neighbours {
+			delete(graph[u], v)
+			for _, w := range neighbours {
+				if w != u {
+					graph[u][w] = struct{}{}
+				}
+			}
+			heap.Push(&h, [2]int{len(graph[u]), u})
+		}
+		graph[v] = nil
+	}
+	return order
+}
+
+// permuteSymmetric returns P*A*P^T, where the element in row i and column j of A moves to row inverse[i] and column inverse[j].
+func (a *CSR) permuteSymmetric(inverse []int) *CSR {
+	c := &COO{Rows: a.Rows, Columns: a.Columns}
+	for i := 0; i < a.Rows; i++ {
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			c.RowIndices = append(c.RowIndices, inverse[i])
+			c.ColumnIndices = append(c.ColumnIndices, inverse[a.ColumnIndices[p]])
+			c.Values = append(c.Values, a.Values[p])
+		}
+	}
+	return c.CSR()
+}
+
+// inversePermutation returns the permutation that undoes perm.
+func inversePermutation(perm []int) []int {
+	inverse := make([]int, len(perm))
+	for k, i := range perm {
+		inverse[i] = k
+	}
+	return inverse
+}
+
+// samePattern reports whether two CSR matrices store elements in exactly the same positions.
+func samePattern(a, b *CSR) bool {
+	if a.Rows != b.Rows || a.Columns != b.Columns || len(a.ColumnIndices) != len(b.ColumnIndices) {
+		return false
+	}
+	for i, p := range a.RowPointers {
+		if b.RowPointers[i] != p {
+			return false
+		}
+	}
+	for i, j := range a.ColumnIndices {
+		if b.ColumnIndices[i] != j {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+// shuffledPoisson returns the Poisson matrix with its rows and columns in a scrambled but fixed order, which destroys the natural banded structure.
+func shuffledPoisson(k int) *CSR {
+	n := k * k
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = (i * 7919) % n
+	}
+	return poisson(k).CSR().permuteSymmetric(perm)
+}
+
+func bandwidth(a *CSR) int {
+	width := 0
+	for i := 0; i < a.Rows; i++ {
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			if d := a.ColumnIndices[p] - i; d > width {
+				width = d
+			}
+		}
+	}
+	return width
+}
+
+func TestOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	a := shuffledPoisson(10)
+	for _, ordering := range []Ordering{OrderingNatural, OrderingRCM, OrderingMinimumDegree} {
+		perm, err := a.Order(ordering)
+		assert.Nil(err)
+		sorted := append([]int(nil), perm...)
+		sort.Ints(sorted)
+		assert.Equal(identity(a.Rows), sorted)
+	}
+
+	perm, _ := a.Order(OrderingRCM)
+	assert.True(bandwidth(a.permuteSymmetric(inversePermutation(perm))) <= 10)
+	assert.True(bandwidth(a) > 10)
+
+	natural, _ := AnalyzeSparseCholesky(a, OrderingNatural)
+	amd, _ := AnalyzeSparseCholesky(a, OrderingMinimumDegree)
+	assert.True(amd.NonZeros() < natural.NonZeros())
+
+	_, err := (&COO{Rows: 2, Columns: 3}).CSR().Order(OrderingRCM)
+	assert.EqualError(err, "Not a square matrix")
+	_, err = a.Order(Ordering(7))
+	assert.EqualError(err, "Unknown ordering")
+}
+
+func BenchmarkMinimumDegree(b *testing.B) {
+	a := shuffledPoisson(30)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Order(OrderingMinimumDegree)
+	}
+}
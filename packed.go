@@ -0,0 +1,761 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Symmetric is a square matrix equal to its transpose, storing only the lower triangle packed by row in n*(n+1)/2 values.
+type Symmetric struct {
+	Size     int
+	Elements []float64
+}
+
+// LowerTriangular is a square matrix whose elements above the diagonal are all zero, storing the lower triangle packed by row.
+type LowerTriangular struct {
+	Size     int
+	Elements []float64
+}
+
+// UpperTriangular is a square matrix whose elements below the diagonal are all zero, storing the upper triangle packed by row.
+type UpperTriangular struct {
+	Size     int
+	Elements []float64
+}
+
+// packedSize returns the number of elements in the packed triangle of an n by n matrix.
+func packedSize(n int) int {
+	return n * (n + 1) / 2
+}
+
+// newPacked checks the dimensions of a packed matrix, allocating zeros if elements is nil.
+func newPacked(size int, elements []float64) ([]float64, error) {
+	if size < 1 {
+		return nil, errors.New("Incorrect matrix dimensions")
+	}
+	if elements == nil {
+		return make([]float64, packedSize(size)), nil
+	}
+	if len(elements) != packedSize(size) {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+	return elements, nil
+}
+
+// NewSymmetric will return a symmetric matrix with the given packed lower triangle, or a zero matrix if elements is nil.
+func NewSymmetric(size int, elements []float64) (*Symmetric, error) {
+	elements, err := newPacked(size, elements)
+	if err != nil {
+		return nil, err
+	}
+	return &Symmetric{Size: size, Elements: elements}, nil
+}
+
+// NewLowerTriangular will return a lower triangular matrix with the given packed lower triangle, or a zero matrix if elements is nil.
+func NewLowerTriangular(size int, elements []float64) (*LowerTriangular, error) {
+	elements, err := newPacked(size, elements)
+	if err != nil {
+		return nil, err
+	}
+	return &LowerTriangular{Size: size, Elements: elements}, nil
+}
+
+// NewUpperTriangular will return an upper triangular matrix with the given packed upper triangle, or a zero matrix if elements is nil.
+func NewUpperTriangular(size int, elements []float64) (*UpperTriangular, error) {
+	elements, err := newPacked(size, elements)
+	if err != nil {
+		return nil, err
+	}
+	return &UpperTriangular{Size: size, Elements: elements}, nil
+}
+
+// Symmetric will return the matrix in packed symmetric storage, or an error if it is not symmetric.
+func (m MatrixStruct) Symmetric() (*Symmetric, error) {
+	if !m.IsSymmetric() {
+		return nil, errors.New("Not a symmetric matrix")
+	}
+
+	// The two triangles are averaged so that round off in a nearly symmetric matrix is not lost.
+	n := m.Rows
+	s, _ := NewSymmetric(n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			s.Elements[lowerIndex(i, j)] = (m.Elements[i*n+j] + m.Elements[j*n+i]) / 2
+		}
+	}
+	return s, nil
+}
+
+// LowerTriangular will return the matrix in packed lower triangular storage, or an error if it has nonzero elements above the diagonal.
+func (m MatrixStruct) LowerTriangular() (*LowerTriangular, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+	if !m.IsLowerTriangular() {
+		return nil, errors.New("Not a lower triangular matrix")
+	}
+
+	return m.packLower(), nil
+}
+
+// UpperTriangular will return the matrix in packed upper triangular storage, or an error if it has nonzero elements below the diagonal.
+func (m MatrixStruct) UpperTriangular() (*UpperTriangular, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+	if !m.IsUpperTriangular() {
+		return nil, errors.New("Not an upper triangular matrix")
+	}
+
+	return m.packUpper(), nil
+}
+
+// packLower copies the lower triangle of a square matrix into packed storage without checking the upper triangle.
+func (m MatrixStruct) packLower() *LowerTriangular {
+	n := m.Rows
+	l, _ := NewLowerTriangular(n, nil)
+	for i := 0; i < n; i++ {
+		copy(l.Elements[lowerIndex(i, 0):], m.Elements[i*n:i*n+i+1])
+	}
+	return l
+}
+
+// packUpper copies the upper triangle of a square matrix into packed storage without checking the lower triangle.
+func (m MatrixStruct) packUpper() *UpperTriangular {
+	n := m.Rows
+	u, _ := NewUpperTriangular(n, nil)
+	for i := 0; i < n; i++ {
+		copy(u.Elements[u.index(i, i):], m.Elements[i*n+i:(i+1)*n])
+	}
+	return u
+}
+
+// Solve will return the matrix x that satisfies A*x = b, using the packed kernels where A has a packed type.
+func Solve(A Interface, b *MatrixStruct) (*MatrixStruct, error) {
+	switch a := A.(type) {
+	case *Symmetric:
+		return a.Solve(b)
+	case *LowerTriangular:
+		return a.Solve(b)
+	case *UpperTriangular:
+		return a.Solve(b)
+	case *MatrixStruct:
+		return a.Solve(b)
+	case MatrixStruct:
+		return a.Solve(b)
+	}
+	return A.Dense().Solve(b)
+}
+
+// Multiply will return the product A*b, using the packed kernels where A has a packed type.
+func Multiply(A Interface, b *MatrixStruct) (*MatrixStruct, error) {
+	switch a := A.(type) {
+	case *Symmetric:
+		return a.Multiply(b)
+	case *LowerTriangular:
+		return a.Multiply(b)
+	case *UpperTriangular:
+		return a.Multiply(b)
+	case *MatrixStruct:
+		return a.Multiply(b)
+	case MatrixStruct:
+		return a.Multiply(b)
+	}
+
+	// Anything else is multiplied with MulVec one column at a time.
+	rows, columns := A.Dims()
+	if b.Rows != columns {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+	product, _ := Zeros(rows, b.Columns)
+	column := make([]float64, columns)
+	dst := make([]float64, rows)
+	for c := 0; c < b.Columns; c++ {
+		for i := range column {
+			column[i] = b.Elements[i*b.Columns+c]
+		}
+		A.MulVec(dst, column)
+		for i, v := range dst {
+			product.Elements[i*b.Columns+c] = v
+		}
+	}
+	return product, nil
+}
+
+// Inverse will return the inverse of A, in the same packed type when A has one and as a MatrixStruct otherwise.
+func Inverse(A Interface) (Interface, error) {
+	// Each case returns its own nil on error, since a nil pointer stored in an Interface is not a nil Interface.
+	switch a := A.(type) {
+	case *Symmetric:
+		inverse, err := a.Inverse()
+		if err != nil {
+			return nil, err
+		}
+		return inverse, nil
+	case *LowerTriangular:
+		inverse, err := a.Inverse()
+		if err != nil {
+			return nil, err
+		}
+		return inverse, nil
+	case *UpperTriangular:
+		inverse, err := a.Inverse()
+		if err != nil {
+			return nil, err
+		}
+		return inverse, nil
+	case *MatrixStruct:
+		inverse, err := a.Inverse()
+		if err != nil {
+			return nil, err
+		}
+		return inverse, nil
+	case MatrixStruct:
+		inverse, err := a.Inverse()
+		if err != nil {
+			return nil, err
+		}
+		return inverse, nil
+	}
+
+	inverse, err := A.Dense().Inverse()
+	if err != nil {
+		return nil, err
+	}
+	return inverse, nil
+}
+
+// lowerIndex returns the position of the element in row i and column j, with j <= i, of a packed lower triangle.
+func lowerIndex(i, j int) int {
+	return i*(i+1)/2 + j
+}
+
+// outOfBounds returns the error for indexes outside an n by n matrix.
+func outOfBounds(n, y, x int) error {
+	if y < 0 || y >= n || x < 0 || x >= n {
+		return fmt.Errorf("The indexes must be in the bounds of the matrix.\nMatrix is %dx%d, indexes are %d,%d", n, n, y, x)
+	}
+	return nil
+}
+
+// Dims will return the number of rows and columns.
+func (s *Symmetric) Dims() (rows, columns int) {
+	return s.Size, s.Size
+}
+
+// GetValue will return the element in row y and column x.
+func (s *Symmetric) GetValue(y, x int) (float64, error) {
+	if err := outOfBounds(s.Size, y, x); err != nil {
+		return 0, err
+	}
+	if x > y {
+		y, x = x, y
+	}
+	return s.Elements[lowerIndex(y, x)], nil
+}
+
+// SetValue will set the element in row y and column x, and so also the element in row x and column y.
+func (s *Symmetric) SetValue(y, x int, value float64) error {
+	if err := outOfBounds(s.Size, y, x); err != nil {
+		return err
+	}
+	if x > y {
+		y, x = x, y
+	}
+	s.Elements[lowerIndex(y, x)] = value
+	return nil
+}
+
+// MulVec will write the product of the matrix and x into dst, reading each stored element once for both of the positions it represents.
+func (s *Symmetric) MulVec(dst, x []float64) {
+	n := s.Size
+	for i := range dst[:n] {
+		dst[i] = 0
+	}
+	for i := 0; i < n; i++ {
+		row := s.Elements[lowerIndex(i, 0):]
+		sum := row[i] * x[i]
+		for j := 0; j < i; j++ {
+			sum += row[j] * x[j]
+			dst[j] += row[j] * x[i]
+		}
+		dst[i] += sum
+	}
+}
+
+// T will return a copy of the matrix, which is its own transpose.
+func (s *Symmetric) T() Interface {
+	return s.Clone()
+}
+
+// Clone will return a copy of the matrix.
+func (s *Symmetric) Clone() *Symmetric {
+	return &Symmetric{Size: s.Size, Elements: append([]float64(nil), s.Elements...)}
+}
+
+// Dense will return the matrix as a MatrixStruct.
+func (s *Symmetric) Dense() *MatrixStruct {
+	n := s.Size
+	m, _ := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			v := s.Elements[lowerIndex(i, j)]
+			m.Elements[i*n+j] = v
+			m.Elements[j*n+i] = v
+		}
+	}
+	return m
+}
+
+// CSR will return the nonzero elements of the matrix in compressed sparse row format.
+func (s *Symmetric) CSR() *CSR {
+	n := s.Size
+	// Row i is read from packed row i up to the diagonal and from column i of the later rows after it.
+	a := &CSR{Rows: n, Columns: n, RowPointers: make([]int, n+1)}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var value float64
+			if j <= i {
+				value = s.Elements[lowerIndex(i, j)]
+			} else {
+				value = s.Elements[lowerIndex(j, i)]
+			}
+			if value != 0 {
+				a.ColumnIndices = append(a.ColumnIndices, j)
+				a.Values = append(a.Values, value)
+			}
+		}
+		a.RowPointers[i+1] = len(a.Values)
+	}
+	return a
+}
+
+// Multiply will return the product of the matrix and n.
+func (s *Symmetric) Multiply(n *MatrixStruct) (*MatrixStruct, error) {
+	if n.Rows != s.Size {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	product, _ := Zeros(s.Size, n.Columns)
+	column := make([]float64, s.Size)
+	dst := make([]float64, s.Size)
+	for c := 0; c < n.Columns; c++ {
+		for i := range column {
+			column[i] = n.Elements[i*n.Columns+c]
+		}
+		s.MulVec(dst, column)
+		for i, v := range dst {
+			product.Elements[i*n.Columns+c] = v
+		}
+	}
+	return product, nil
+}
+
+// Cholesky will return the packed lower triangular factor L that satisfies s = L*L^T, or an error if the matrix is not positive definite.
+func (s *Symmetric) Cholesky() (*LowerTriangular, error) {
+	n := s.Size
+	L, _ := NewLowerTriangular(n, nil)
+	l := L.Elements
+
+	for j := 0; j < n; j++ {
+		rj := lowerIndex(j, 0)
+		d := s.Elements[rj+j]
+		for k := 0; k < j; k++ {
+			d -= l[rj+k] * l[rj+k]
+		}
+		if d <= 0 || math.IsNaN(d) {
+			return nil, errors.New("Matrix is not positive definite")
+		}
+		d = math.Sqrt(d)
+		l[rj+j] = d
+
+		for i := j + 1; i < n; i++ {
+			ri := lowerIndex(i, 0)
+			sum := s.Elements[ri+j]
+			for k := 0; k < j; k++ {
+				sum -= l[ri+k] * l[rj+k]
+			}
+			l[ri+j] = sum / d
+		}
+	}
+	return L, nil
+}
+
+// Solve will return the matrix x that satisfies s*x = b.
+func (s *Symmetric) Solve(b *MatrixStruct) (*MatrixStruct, error) {
+	if b.Rows != s.Size {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	// Only a matrix that is not positive definite falls back to the dense LU decomposition.
+	L, err := s.Cholesky()
+	if err != nil {
+		return s.Dense().Solve(b)
+	}
+
+	x := b.Clone()
+	if err := L.solve(x); err != nil {
+		return nil, err
+	}
+	return x, L.solveTranspose(x)
+}
+
+// Inverse will return the inverse of the matrix, which is also symmetric.
+func (s *Symmetric) Inverse() (*Symmetric, error) {
+	// Positive definite matrices are inverted as L^-T * L^-1, anything else falls back to the dense inverse.
+	n := s.Size
+	L, err := s.Cholesky()
+	if err != nil {
+		inverse, err := s.Dense().Inverse()
+		if err != nil {
+			return nil, err
+		}
+		inverse.symmetrize()
+		return inverse.Symmetric()
+	}
+
+	LInv, err := L.Inverse()
+	if err != nil {
+		return nil, err
+	}
+	inverse, _ := NewSymmetric(n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := float64(0)
+			for k := i; k < n; k++ {
+				rk := lowerIndex(k, 0)
+				sum += LInv.Elements[rk+i] * LInv.Elements[rk+j]
+			}
+			inverse.Elements[lowerIndex(i, j)] = sum
+		}
+	}
+	return inverse, nil
+}
+
+// Dims will return the number of rows and columns.
+func (l *LowerTriangular) Dims() (rows, columns int) {
+	return l.Size, l.Size
+}
+
+// GetValue will return the element in row y and column x.
+func (l *LowerTriangular) GetValue(y, x int) (float64, error) {
+	if err := outOfBounds(l.Size, y, x); err != nil {
+		return 0, err
+	}
+	if x > y {
+		return 0, nil
+	}
+	return l.Elements[lowerIndex(y, x)], nil
+}
+
+// SetValue will set the element in row y and column x, which must be on or below the diagonal.
+func (l *LowerTriangular) SetValue(y, x int, value float64) error {
+	if err := outOfBounds(l.Size, y, x); err != nil {
+		return err
+	}
+	if x > y {
+		return errors.New("Element is outside the triangle of the matrix")
+	}
+	l.Elements[lowerIndex(y, x)] = value
+	return nil
+}
+
+// MulVec will write the product of the matrix and x into dst.
+func (l *LowerTriangular) MulVec(dst, x []float64) {
+	for i := 0; i < l.Size; i++ {
+		row := l.Elements[lowerIndex(i, 0):]
+		sum := float64(0)
+		for j := 0; j <= i; j++ {
+			sum += row[j] * x[j]
+		}
+		dst[i] = sum
+	}
+}
+
+// T will return the transpose of the matrix, which satisfies Interface.
+func (l *LowerTriangular) T() Interface {
+	return l.Transpose()
+}
+
+// Transpose will return the transpose of the matrix, which is upper triangular.
+func (l *LowerTriangular) Transpose() *UpperTriangular {
+	u, _ := NewUpperTriangular(l.Size, nil)
+	for i := 0; i < l.Size; i++ {
+		for j := 0; j <= i; j++ {
+			u.Elements[u.index(j, i)] = l.Elements[lowerIndex(i, j)]
+		}
+	}
+	return u
+}
+
+// Dense will return the matrix as a MatrixStruct.
+func (l *LowerTriangular) Dense() *MatrixStruct {
+	n := l.Size
+	m, _ := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		copy(m.Elements[i*n:], l.Elements[lowerIndex(i, 0):lowerIndex(i, 0)+i+1])
+	}
+	return m
+}
+
+// CSR will return the nonzero elements of the matrix in compressed sparse row format.
+func (l *LowerTriangular) CSR() *CSR {
+	n := l.Size
+	a := &CSR{Rows: n, Columns: n, RowPointers: make([]int, n+1)}
+	for i := 0; i < n; i++ {
+		for j, value := range l.Elements[lowerIndex(i, 0) : lowerIndex(i, 0)+i+1] {
+			if value != 0 {
+				a.ColumnIndices = append(a.ColumnIndices, j)
+				a.Values = append(a.Values, value)
+			}
+		}
+		a.RowPointers[i+1] = len(a.Values)
+	}
+	return a
+}
+
+// Multiply will return the product of the matrix and n, skipping the zero upper triangle.
+func (l *LowerTriangular) Multiply(n *MatrixStruct) (*MatrixStruct, error) {
+	if n.Rows != l.Size {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	product, _ := Zeros(l.Size, n.Columns)
+	for i := 0; i < l.Size; i++ {
+		row := l.Elements[lowerIndex(i, 0):]
+		out := product.Elements[i*n.Columns : (i+1)*n.Columns]
+		for k := 0; k <= i; k++ {
+			v := row[k]
+			for c, w := range n.Elements[k*n.Columns : (k+1)*n.Columns] {
+				out[c] += v * w
+			}
+		}
+	}
+	return product, nil
+}
+
+// Solve will return the matrix x that satisfies l*x = b using forward substitution.
+func (l *LowerTriangular) Solve(b *MatrixStruct) (*MatrixStruct, error) {
+	if b.Rows != l.Size {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	x := b.Clone()
+	return x, l.solve(x)
+}
+
+// Inverse will return the inverse of the matrix, which is also lower triangular, computed one column at a time by forward substitution.
+func (l *LowerTriangular) Inverse() (*LowerTriangular, error) {
+	n := l.Size
+	inverse, _ := NewLowerTriangular(n, nil)
+	for j := 0; j < n; j++ {
+		d := l.Elements[lowerIndex(j, j)]
+		if d == 0 {
+			return nil, errors.New("Matrix is singular")
+		}
+		inverse.Elements[lowerIndex(j, j)] = 1 / d
+
+		for i := j + 1; i < n; i++ {
+			ri := lowerIndex(i, 0)
+			sum := float64(0)
+			for k := j; k < i; k++ {
+				sum -= l.Elements[ri+k] * inverse.Elements[lowerIndex(k, j)]
+			}
+			// Scaling by the reciprocal matches the rounding of the dense TriangleInverse that delegates here.
+			inverse.Elements[ri+j] = sum * (1 / l.Elements[ri+i])
+		}
+	}
+	return inverse, nil
+}
+
+// solve overwrites x with the solution of l*x = x.
+func (l *LowerTriangular) solve(x *MatrixStruct) error {
+	for i := 0; i < l.Size; i++ {
+		row := l.Elements[lowerIndex(i, 0):]
+		if row[i] == 0 {
+			return errors.New("Matrix is singular")
+		}
+		for c := 0; c < x.Columns; c++ {
+			sum := x.Elements[i*x.Columns+c]
+			for k := 0; k < i; k++ {
+				sum -= row[k] * x.Elements[k*x.Columns+c]
+			}
+			x.Elements[i*x.Columns+c] = sum / row[i]
+		}
+	}
+	return nil
+}
+
+// solveTranspose overwrites x with the solution of l^T*x = x, walking the packed rows of l as the columns of l^T.
+func (l *LowerTriangular) solveTranspose(x *MatrixStruct) error {
+	for i := l.Size - 1; i >= 0; i-- {
+		row := l.Elements[lowerIndex(i, 0):]
+		if row[i] == 0 {
+			return errors.New("Matrix is singular")
+		}
+		for c := 0; c < x.Columns; c++ {
+			x.Elements[i*x.Columns+c] /= row[i]
+			v := x.Elements[i*x.Columns+c]
+			for k := 0; k < i; k++ {
+				x.Elements[k*x.Columns+c] -= row[k] * v
+			}
+		}
+	}
+	return nil
+}
+
+// index returns the position in Elements of the element in row i and column j, with j >= i.
+func (u *UpperTriangular) index(i, j int) int {
+	// Row i holds columns i to n-1 and starts at i*n-i*(i-1)/2.
+	return i*u.Size - i*(i-1)/2 + j - i
+}
+
+// Dims will return the number of rows and columns.
+func (u *UpperTriangular) Dims() (rows, columns int) {
+	return u.Size, u.Size
+}
+
+// GetValue will return the element in row y and column x.
+func (u *UpperTriangular) GetValue(y, x int) (float64, error) {
+	if err := outOfBounds(u.Size, y, x); err != nil {
+		return 0, err
+	}
+	if x < y {
+		return 0, nil
+	}
+	return u.Elements[u.index(y, x)], nil
+}
+
+// SetValue will set the element in row y and column x, which must be on or above the diagonal.
+func (u *UpperTriangular) SetValue(y, x int, value float64) error {
+	if err := outOfBounds(u.Size, y, x); err != nil {
+		return err
+	}
+	if x < y {
+		return errors.New("Element is outside the triangle of the matrix")
+	}
+	u.Elements[u.index(y, x)] = value
+	return nil
+}
+
+// MulVec will write the product of the matrix and x into dst.
+func (u *UpperTriangular) MulVec(dst, x []float64) {
+	for i := 0; i < u.Size; i++ {
+		row := u.Elements[u.index(i, i):]
+		sum := float64(0)
+		for j, v := range row[:u.Size-i] {
+			sum += v * x[i+j]
+		}
+		dst[i] = sum
+	}
+}
+
+// T will return the transpose of the matrix, which satisfies Interface.
+func (u *UpperTriangular) T() Interface {
+	return u.Transpose()
+}
+
+// Transpose will return the transpose of the matrix, which is lower triangular.
+func (u *UpperTriangular) Transpose() *LowerTriangular {
+	l, _ := NewLowerTriangular(u.Size, nil)
+	for i := 0; i < u.Size; i++ {
+		for j := i; j < u.Size; j++ {
+			l.Elements[lowerIndex(j, i)] = u.Elements[u.index(i, j)]
+		}
+	}
+	return l
+}
+
+// Dense will return the matrix as a MatrixStruct.
+func (u *UpperTriangular) Dense() *MatrixStruct {
+	n := u.Size
+	m, _ := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		copy(m.Elements[i*n+i:], u.Elements[u.index(i, i):u.index(i, i)+n-i])
+	}
+	return m
+}
+
+// CSR will return the nonzero elements of the matrix in compressed sparse row format.
+func (u *UpperTriangular) CSR() *CSR {
+	n := u.Size
+	a := &CSR{Rows: n, Columns: n, RowPointers: make([]int, n+1)}
+	for i := 0; i < n; i++ {
+		for j, value := range u.Elements[u.index(i, i) : u.index(i, i)+n-i] {
+			if value != 0 {
+				a.ColumnIndices = append(a.ColumnIndices, i+j)
+				a.Values = append(a.Values, value)
+			}
+		}
+		a.RowPointers[i+1] = len(a.Values)
+	}
+	return a
+}
+
+// Multiply will return the product of the matrix and n, skipping the zero lower triangle.
+func (u *UpperTriangular) Multiply(n *MatrixStruct) (*MatrixStruct, error) {
+	if n.Rows != u.Size {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	product, _ := Zeros(u.Size, n.Columns)
+	for i := 0; i < u.Size; i++ {
+		row := u.Elements[u.index(i, i):]
+		out := product.Elements[i*n.Columns : (i+1)*n.Columns]
+		for k := i; k < u.Size; k++ {
+			v := row[k-i]
+			for c, w := range n.Elements[k*n.Columns : (k+1)*n.Columns] {
+				out[c] += v * w
+			}
+		}
+	}
+	return product, nil
+}
+
+// Solve will return the matrix x that satisfies u*x = b using back substitution.
+func (u *UpperTriangular) Solve(b *MatrixStruct) (*MatrixStruct, error) {
+	if b.Rows != u.Size {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	x := b.Clone()
+	for i := u.Size - 1; i >= 0; i-- {
+		row := u.Elements[u.index(i, i):]
+		if row[0] == 0 {
+			return nil, errors.New("Matrix is singular")
+		}
+		for c := 0; c < x.Columns; c++ {
+			sum := x.Elements[i*x.Columns+c]
+			for k := i + 1; k < u.Size; k++ {
+				sum -= row[k-i] * x.Elements[k*x.Columns+c]
+			}
+			x.Elements[i*x.Columns+c] = sum / row[0]
+		}
+	}
+	return x, nil
+}
+
+// Inverse will return the inverse of the matrix, which is also upper triangular, computed one column at a time by back substitution.
+func (u *UpperTriangular) Inverse() (*UpperTriangular, error) {
+	n := u.Size
+	inverse, _ := NewUpperTriangular(n, nil)
+	for j := 0; j < n; j++ {
+		d := u.Elements[u.index(j, j)]
+		if d == 0 {
+			return nil, errors.New("Matrix is singular")
+		}
+		inverse.Elements[inverse.index(j, j)] = 1 / d
+
+		for i := j - 1; i >= 0; i-- {
+			row := u.Elements[u.index(i, i):]
+			sum := float64(0)
+			for k := i + 1; k <= j; k++ {
+				sum -= row[k-i] * inverse.Elements[inverse.index(k, j)]
+			}
+			// Scaling by the reciprocal matches the rounding of the dense TriangleInverse that delegates here.
+			inverse.Elements[inverse.index(i, j)] = sum * (1 / row[0])
+		}
+	}
+	return inverse, nil
+}
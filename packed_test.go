@@ -0,0 +1,252 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSymmetric(t *testing.T) {
+	assert := assert.New(t)
+
+	m, _ := Matrix(3, 3, []float64{
+		4, 1, 2,
+		1, 5, 3,
+		2, 3, 6,
+	})
+	S, err := m.Symmetric()
+	assert.Nil(err)
+	assert.Equal([]float64{4, 1, 5, 2, 3, 6}, S.Elements)
+	assert.Equal(m.Elements, S.Dense().Elements)
+	assert.Equal(m.Elements, S.T().Dense().Elements)
+
+	value, _ := S.GetValue(0, 2)
+	assert.Equal(2.0, value)
+	assert.Nil(S.SetValue(0, 2, 7))
+	value, _ = S.GetValue(2, 0)
+	assert.Equal(7.0, value)
+	assert.Nil(S.SetValue(2, 0, 2))
+	_, err = S.GetValue(3, 0)
+	assert.NotNil(err)
+
+	x := []float64{1, -2, 3}
+	dst := make([]float64, 3)
+	S.MulVec(dst, x)
+	b := &MatrixStruct{Rows: 3, Columns: 1, Capacity: 3, Elements: x}
+	expected, _ := m.Multiply(b)
+	assert.InDeltaSlice(expected.Elements, dst, 1e-12)
+	product, err := S.Multiply(m)
+	assert.Nil(err)
+	expected, _ = m.Multiply(m)
+	assert.InDeltaSlice(expected.Elements, product.Elements, 1e-12)
+
+	_, err = NewSymmetric(3, []float64{1, 2})
+	assert.EqualError(err, "matrix dimensions do not agree")
+	_, err = NewSymmetric(0, nil)
+	assert.EqualError(err, "Incorrect matrix dimensions")
+	nonsymmetric, _ := Matrix(2, 2, []float64{1, 2, 3, 4})
+	_, err = nonsymmetric.Symmetric()
+	assert.EqualError(err, "Not a symmetric matrix")
+}
+
+func TestSymmetricSolve(t *testing.T) {
+	assert := assert.New(t)
+
+	A := poisson(5)
+	S, _ := A.Symmetric()
+	b := &MatrixStruct{Rows: 25, Columns: 2, Capacity: 50, Elements: append(sequence(25), sequence(25)...)}
+	x, err := S.Solve(b)
+	assert.Nil(err)
+	expected, _ := A.Solve(b)
+	assert.InDeltaSlice(expected.Elements, x.Elements, 1e-10)
+
+	L, err := S.Cholesky()
+	assert.Nil(err)
+	LLt, _ := L.Multiply(L.Transpose().Dense())
+	assert.InDeltaSlice(A.Elements, LLt.Elements, 1e-12)
+
+	inverse, err := S.Inverse()
+	assert.Nil(err)
+	identity, _ := inverse.Multiply(A)
+	eye, _ := Eye(25, 25)
+	assert.InDeltaSlice(eye.Elements, identity.Elements, 1e-10)
+
+	// An indefinite matrix falls back to the dense LU decomposition.
+	indefinite, _ := Matrix(2, 2, []float64{1, 2, 2, 1})
+	S, _ = indefinite.Symmetric()
+	_, err = S.Cholesky()
+	assert.EqualError(err, "Matrix is not positive definite")
+	x, err = S.Solve(&MatrixStruct{Rows: 2, Columns: 1, Capacity: 2, Elements: []float64{3, 3}})
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1, 1}, x.Elements, 1e-12)
+	inverse, err = S.Inverse()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{-1.0 / 3, 2.0 / 3, -1.0 / 3}, inverse.Elements, 1e-12)
+}
+
+func TestTriangularTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	m, _ := Matrix(4, 4, []float64{1, 2, 3, 4, 0, 5, 6, 7, 0, 0, 8, 9, 0, 0, 0, 10})
+	U, err := m.UpperTriangular()
+	assert.Nil(err)
+	assert.Equal([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, U.Elements)
+	assert.Equal(m.Elements, U.Dense().Elements)
+
+	L := U.Transpose()
+	assert.Equal(m.Transpose().Elements, L.Dense().Elements)
+	back, err := m.Transpose().LowerTriangular()
+	assert.Nil(err)
+	assert.Equal(L, back)
+	assert.Equal(U, L.Transpose())
+
+	value, _ := U.GetValue(3, 0)
+	assert.Equal(0.0, value)
+	value, _ = L.GetValue(3, 0)
+	assert.Equal(4.0, value)
+	assert.EqualError(U.SetValue(1, 0, 1), "Element is outside the triangle of the matrix")
+	assert.EqualError(L.SetValue(0, 1, 1), "Element is outside the triangle of the matrix")
+
+	_, err = m.LowerTriangular()
+	assert.EqualError(err, "Not a lower triangular matrix")
+	_, err = m.Transpose().UpperTriangular()
+	assert.EqualError(err, "Not an upper triangular matrix")
+
+	x := []float64{1, -2, 3, -4}
+	b := &MatrixStruct{Rows: 4, Columns: 1, Capacity: 4, Elements: x}
+	for _, T := range []interface {
+		Interface
+		Multiply(*MatrixStruct) (*MatrixStruct, error)
+		Solve(*MatrixStruct) (*MatrixStruct, error)
+	}{U, L} {
+		dense := T.Dense()
+		dst := make([]float64, 4)
+		T.MulVec(dst, x)
+		expected, _ := dense.Multiply(b)
+		assert.InDeltaSlice(expected.Elements, dst, 1e-12)
+
+		product, _ := T.Multiply(dense)
+		expected, _ = dense.Multiply(dense)
+		assert.InDeltaSlice(expected.Elements, product.Elements, 1e-12)
+
+		solution, err := T.Solve(b)
+		assert.Nil(err)
+		expected, _ = dense.TriangleSolve(b)
+		assert.InDeltaSlice(expected.Elements, solution.Elements, 1e-12)
+	}
+
+	UInv, err := U.Inverse()
+	assert.Nil(err)
+	expected, _ := m.TriangleInverse()
+	assert.InDeltaSlice(expected.Elements, UInv.Dense().Elements, 1e-12)
+	LInv, err := L.Inverse()
+	assert.Nil(err)
+	expected, _ = m.Transpose().TriangleInverse()
+	assert.InDeltaSlice(expected.Elements, LInv.Dense().Elements, 1e-12)
+
+	singular, _ := NewUpperTriangular(2, []float64{1, 2, 0})
+	_, err = singular.Inverse()
+	assert.EqualError(err, "Matrix is singular")
+	_, err = singular.Solve(&MatrixStruct{Rows: 2, Columns: 1, Capacity: 2, Elements: []float64{1, 1}})
+	assert.EqualError(err, "Matrix is singular")
+	_, err = singular.Transpose().Inverse()
+	assert.EqualError(err, "Matrix is singular")
+}
+
+func TestDispatch(t *testing.T) {
+	assert := assert.New(t)
+
+	m, _ := Matrix(3, 3, []float64{
+		4, 1, 2,
+		1, 5, 3,
+		2, 3, 6,
+	})
+	lower, _ := Matrix(3, 3, []float64{
+		2, 0, 0,
+		1, 4, 0,
+		1, 2, 5,
+	})
+	b, _ := Matrix(3, 2, []float64{1, 2, 3, 4, 5, 6})
+
+	S, _ := m.Symmetric()
+	L, _ := lower.LowerTriangular()
+	U := L.Transpose()
+	for _, A := range []Interface{S, L, U, m, *m, spline(3)} {
+		dense := A.Dense()
+
+		x, err := Solve(A, b)
+		assert.Nil(err)
+		expected, _ := dense.Solve(b)
+		assert.InDeltaSlice(expected.Elements, x.Elements, 1e-12)
+
+		product, err := Multiply(A, b)
+		assert.Nil(err)
+		expected, _ = dense.Multiply(b)
+		assert.InDeltaSlice(expected.Elements, product.Elements, 1e-12)
+
+		inverse, err := Inverse(A)
+		assert.Nil(err)
+		expected, _ = dense.Inverse()
+		assert.InDeltaSlice(expected.Elements, inverse.Dense().Elements, 1e-12)
+	}
+
+	// The inverse keeps the packed type of its argument.
+	inverse, _ := Inverse(S)
+	assert.IsType(&Symmetric{}, inverse)
+	inverse, _ = Inverse(L)
+	assert.IsType(&LowerTriangular{}, inverse)
+	inverse, _ = Inverse(U)
+	assert.IsType(&UpperTriangular{}, inverse)
+	inverse, _ = Inverse(*m)
+	assert.IsType(&MatrixStruct{}, inverse)
+
+	singular, _ := NewUpperTriangular(2, []float64{1, 2, 0})
+	inverse, err := Inverse(singular)
+	assert.Nil(inverse)
+	assert.NotNil(err)
+	inverse, err = Inverse(singular.Dense())
+	assert.Nil(inverse)
+	assert.NotNil(err)
+
+	_, err = Multiply(spline(4), b)
+	assert.NotNil(err)
+}
+
+func TestTriangle(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		elements     []float64
+		upper, lower bool
+	}{
+		{[]float64{1, 0, 0, 2}, true, true},
+		{[]float64{1, 3, 0, 2}, true, false},
+		{[]float64{1, 0, 3, 2}, false, true},
+		{[]float64{1, 3, 3, 2}, false, false},
+	}
+	for _, c := range cases {
+		m, _ := Matrix(2, 2, c.elements)
+		upper, lower := m.triangle()
+		assert.Equal(c.upper, upper)
+		assert.Equal(c.lower, lower)
+		assert.Equal(m.IsUpperTriangular(), upper)
+		assert.Equal(m.IsLowerTriangular(), lower)
+	}
+}
+
+func BenchmarkSymmetricCholesky(b *testing.B) {
+	S, _ := poisson(10).Symmetric()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		S.Cholesky()
+	}
+}
+
+func BenchmarkUpperTriangularInverse(b *testing.B) {
+	S, _ := poisson(10).Symmetric()
+	L, _ := S.Cholesky()
+	U := L.Transpose()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		U.Inverse()
+	}
+}
@@ -0,0 +1,87 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// nearestSPDIterations limits how many times NearestSPD shifts the diagonal to make the projection positive definite.
+const nearestSPDIterations = 100
+
+// Polar will return the polar decomposition m = U*P, where U has orthonormal columns and P is symmetric positive semidefinite.
+func (m MatrixStruct) Polar() (U, P *MatrixStruct, err error) {
+	// m = W*Sigma*V^T gives U = W*V^T and P = V*Sigma*V^T. For a wide matrix U has orthonormal rows instead.
+	w, s, v, err := m.svd(true)
+	if err != nil {
+		return nil, nil, err
+	}
+	U, _ = w.Multiply(v.Transpose())
+
+	vs := v.Clone()
+	k := len(s)
+	for i := 0; i < v.Rows; i++ {
+		for j := 0; j < k; j++ {
+			vs.Elements[i*k+j] *= s[j]
+		}
+	}
+	P, _ = vs.Multiply(v.Transpose())
+	P.symmetrize()
+	return U, P, nil
+}
+
+// NearestOrthogonal will return the orthogonal matrix closest to a square matrix in the Frobenius norm.
+func (m MatrixStruct) NearestOrthogonal() (*MatrixStruct, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+
+	// The closest orthogonal matrix is the orthogonal factor of the polar decomposition.
+	U, _, err := m.Polar()
+	if err != nil {
+		return nil, err
+	}
+	return U, nil
+}
+
+// NearestSPD will return the symmetric positive definite matrix closest to a square matrix in the Frobenius norm.
+func (m MatrixStruct) NearestSPD() (*MatrixStruct, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+
+	// Higham's method projects the symmetric part B = U*H onto the positive semidefinite cone as (B + H)/2.
+	B, _ := m.Add(m.Transpose())
+	B = B.ScalarMultiply(0.5)
+	_, H, err := B.Polar()
+	if err != nil {
+		return nil, err
+	}
+
+	X, _ := B.Add(H)
+	X = X.ScalarMultiply(0.5)
+	X.symmetrize()
+
+	// Rounding can leave the projection semidefinite, so shift the diagonal until Cholesky succeeds.
+	n := m.Rows
+	for k := 1; ; k++ {
+		if _, err := X.Cholesky(); err == nil {
+			return X, nil
+		}
+		if k > nearestSPDIterations {
+			return nil, errors.New("Could not find a positive definite matrix")
+		}
+
+		values, _, err := X.EigenSym()
+		if err != nil {
+			return nil, err
+		}
+		minimum := values[0]
+		shift := -minimum*float64(k*k) + math.Nextafter(math.Abs(minimum), math.Inf(1)) - math.Abs(minimum)
+		if floor := epsilon * math.Max(X.maxAbs(), 1); shift < floor {
+			shift = floor
+		}
+		for i := 0; i < n; i++ {
+			X.Elements[i*n+i] += shift
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestPolar(t *testing.T) {
+	assert := assert.New(t)
+
+	square, _ := Matrix(3, 3, []float64{4, 1, -2, 0, 3, 1, 2, -1, 5})
+	tall, _ := Matrix(4, 2, []float64{1, 2, 3, 4, 5, 6, 7, 8})
+	wide, _ := Matrix(2, 3, []float64{1, 0, 2, -1, 3, 1})
+	for _, m := range []*MatrixStruct{square, tall, wide} {
+		U, P, err := m.Polar()
+		assert.Nil(err)
+		assert.Equal(m.Rows, U.Rows)
+		assert.Equal(m.Columns, U.Columns)
+		assert.Equal(m.Columns, P.Rows)
+		assert.True(P.IsSymmetric())
+
+		product, _ := U.Multiply(P)
+		assert.InDeltaSlice(m.Elements, product.Elements, 1e-12)
+
+		values, _, err := P.EigenSym()
+		assert.Nil(err)
+		assert.True(values[0] > -1e-12)
+
+		if m.Rows >= m.Columns {
+			UTU, _ := U.Transpose().Multiply(U)
+			eye, _ := Eye(m.Columns, m.Columns)
+			assert.InDeltaSlice(eye.Elements, UTU.Elements, 1e-12)
+		} else {
+			UUT, _ := U.Multiply(U.Transpose())
+			eye, _ := Eye(m.Rows, m.Rows)
+			assert.InDeltaSlice(eye.Elements, UUT.Elements, 1e-12)
+		}
+	}
+}
+
+func TestPolarNotConverged(t *testing.T) {
+	assert := assert.New(t)
+
+	m, err := Matrix(2, 2, []float64{1, math.NaN(), 3, 4})
+	assert.Nil(err)
+
+	U, P, err := m.Polar()
+	assert.Nil(U)
+	assert.Nil(P)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+	U, err = m.NearestOrthogonal()
+	assert.Nil(U)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+	P, err = m.NearestSPD()
+	assert.Nil(P)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+}
+
+func BenchmarkPolar(b *testing.B) {
+	m, _ := Matrix(3, 3, []float64{4, 1, -2, 0, 3, 1, 2, -1, 5})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = m.Polar()
+	}
+}
+
+func TestNearestOrthogonal(t *testing.T) {
+	assert := assert.New(t)
+
+	// A rotation about the z axis with some drift added to every element.
+	c, s := math.Cos(0.3), math.Sin(0.3)
+	rotation, err := Matrix(3, 3, []float64{c, -s, 0, s, c, 0, 0, 0, 1})
+	assert.Nil(err)
+	drift, _ := Matrix(3, 3, []float64{1e-4, -2e-4, 3e-5, 5e-5, 1e-4, -1e-4, 2e-5, 0, -3e-4})
+	drifted, _ := rotation.Add(drift)
+
+	Q, err := drifted.NearestOrthogonal()
+	assert.Nil(err)
+	QTQ, _ := Q.Transpose().Multiply(Q)
+	eye, _ := Eye(3, 3)
+	assert.InDeltaSlice(eye.Elements, QTQ.Elements, 1e-14)
+	assert.InDeltaSlice(rotation.Elements, Q.Elements, 1e-3)
+
+	det, err := Q.Det()
+	assert.Nil(err)
+	assert.InDelta(1, det, 1e-14)
+
+	wide, _ := Ones(2, 3)
+	_, err = wide.NearestOrthogonal()
+	assert.NotNil(err)
+}
+
+func BenchmarkNearestOrthogonal(b *testing.B) {
+	m, _ := Matrix(3, 3, []float64{0.9553, -0.2957, 0.0001, 0.2955, 0.9554, -0.0001, 0, 0, 0.9997})
+	for n := 0; n < b.N; n++ {
+		_, _ = m.NearestOrthogonal()
+	}
+}
+
+func TestNearestSPD(t *testing.T) {
+	assert := assert.New(t)
+
+	spd, err := Matrix(3, 3, []float64{4, 1, 0, 1, 3, 1, 0, 1, 2})
+	assert.Nil(err)
+	X, err := spd.NearestSPD()
+	assert.Nil(err)
+	assert.InDeltaSlice(spd.Elements, X.Elements, 1e-12)
+
+	// A covariance matrix with a slightly negative eigenvalue.
+	broken, err := Matrix(3, 3, []float64{1, 0.9, 0.7, 0.9, 1, 0.3, 0.7, 0.3, 1})
+	assert.Nil(err)
+	values, _, err := broken.EigenSym()
+	assert.Nil(err)
+	assert.True(values[0] < 0)
+
+	X, err = broken.NearestSPD()
+	assert.Nil(err)
+	assert.True(X.IsSymmetric())
+	_, err = X.Cholesky()
+	assert.Nil(err)
+	diff, _ := X.Subtract(broken)
+	assert.True(diff.frobenius() < 0.2)
+
+	singular, _ := Zeros(2, 2)
+	X, err = singular.NearestSPD()
+	assert.Nil(err)
+	_, err = X.Cholesky()
+	assert.Nil(err)
+
+	wide, _ := Ones(2, 3)
+	_, err = wide.NearestSPD()
+	assert.NotNil(err)
+}
+
+func BenchmarkNearestSPD(b *testing.B) {
+	m, _ := Matrix(3, 3, []float64{1, 0.9, 0.7, 0.9, 1, 0.3, 0.7, 0.3, 1})
+	for n := 0; n < b.N; n++ {
+		_, _ = m.NearestSPD()
+	}
+}
@@ -0,0 +1,113 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// Pow will return the matrix raised to the integer power k using binary exponentiation, inverting the matrix when k is negative.
+func (m MatrixStruct) Pow(k int) (*MatrixStruct, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+
+	n := m.Rows
+	result, _ := Eye(n, n)
+	base := m.Clone()
+	// The exponent is unsigned so that -k does not overflow when k is math.MinInt64.
+	e := uint64(k)
+	if k < 0 {
+		f := m.luDecompose()
+		if f.singular {
+			return nil, errors.New("Matrix is singular")
+		}
+		var err error
+		base, err = f.solve(result)
+		if err != nil {
+			return nil, err
+		}
+		e = uint64(-(k + 1)) + 1
+	}
+
+	// Products alternate between the live matrix and a scratch buffer so each step reuses memory.
+	scratch, _ := Zeros(n, n)
+	for e > 0 {
+		if e&1 == 1 {
+			multiplyInto(scratch, result, base)
+			result, scratch = scratch, result
+		}
+		e >>= 1
+		if e > 0 {
+			multiplyInto(scratch, base, base)
+			base, scratch = scratch, base
+		}
+	}
+	return result, nil
+}
+
+// Polyval will return the matrix polynomial p(m) = coeffs[0]*m^d + coeffs[1]*m^(d-1) + ... + coeffs[d]*I using the Paterson-Stockmeyer scheme.
+func (m MatrixStruct) Polyval(coeffs []float64) (*MatrixStruct, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+
+	n := m.Rows
+	result, _ := Zeros(n, n)
+	if len(coeffs) == 0 {
+		return result, nil
+	}
+
+	d := len(coeffs) - 1
+	c := func(i int) float64 {
+		return coeffs[d-i]
+	}
+
+	// Precompute m^0 ... m^s, the polynomial is then Horner's method in m^s with blocks of degree below s as coefficients.
+	// This needs about 2*sqrt(d) multiplications instead of the d needed by plain Horner's method.
+	s := int(math.Sqrt(float64(d)))
+	if s < 1 {
+		s = 1
+	}
+	powers := make([]*MatrixStruct, s+1)
+	powers[0], _ = Eye(n, n)
+	powers[1] = m.Clone()
+	for i := 2; i <= s; i++ {
+		powers[i], _ = Zeros(n, n)
+		multiplyInto(powers[i], powers[i-1], powers[1])
+	}
+
+	addBlock := func(dst *MatrixStruct, j int) {
+		for i := 0; i < s && j*s+i <= d; i++ {
+			dst.addScaled(c(j*s+i), powers[i])
+		}
+	}
+
+	r := d / s
+	addBlock(result, r)
+	scratch, _ := Zeros(n, n)
+	for j := r - 1; j >= 0; j-- {
+		multiplyInto(scratch, result, powers[s])
+		result, scratch = scratch, result
+		addBlock(result, j)
+	}
+	return result, nil
+}
+
+// multiplyInto overwrites dst with the product a*b, dst must not share elements with either operand.
+func multiplyInto(dst, a, b *MatrixStruct) {
+	for i := 0; i < a.Rows; i++ {
+		row := dst.Elements[i*dst.Columns : (i+1)*dst.Columns]
+		for j := range row {
+			row[j] = 0
+		}
+		for k := 0; k < a.Columns; k++ {
+			scale := a.Elements[i*a.Columns+k]
+			if scale == 0 {
+				continue
+			}
+			for j, value := range b.Elements[k*b.Columns : (k+1)*b.Columns] {
+				row[j] += scale * value
+			}
+		}
+	}
+}
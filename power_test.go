@@ -0,0 +1,116 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestPow(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{1, 2, 0, 0, 1, -1, 3, 0, 2})
+	assert.Nil(err)
+
+	expected, _ := Eye(3, 3)
+	for k := 0; k <= 9; k++ {
+		P, err := a.Pow(k)
+		assert.Nil(err)
+		assert.InDeltaSlice(expected.Elements, P.Elements, 1e-9)
+		expected, _ = expected.Multiply(a)
+	}
+
+	inv, err := a.Inverse()
+	assert.Nil(err)
+	expected = inv.Clone()
+	for k := 1; k <= 5; k++ {
+		P, err := a.Pow(-k)
+		assert.Nil(err)
+		assert.InDeltaSlice(expected.Elements, P.Elements, 1e-12)
+		expected, _ = expected.Multiply(inv)
+	}
+
+	// The Fibonacci matrix gives F(31) = 1346269 in its corner.
+	fib, err := Matrix(2, 2, []float64{1, 1, 1, 0})
+	assert.Nil(err)
+	P, err := fib.Pow(30)
+	assert.Nil(err)
+	assert.Equal([]float64{1346269, 832040, 832040, 514229}, P.Elements)
+
+	singular, err := Matrix(2, 2, []float64{1, 2, 2, 4})
+	assert.Nil(err)
+	_, err = singular.Pow(-1)
+	assert.NotNil(err)
+
+	wide, _ := Ones(2, 3)
+	_, err = wide.Pow(2)
+	assert.NotNil(err)
+
+	// A cyclic permutation has order three, so the extreme negative powers stay exact. 2^63 is 2 mod 3, making C^(-2^63) = C^-2 = C.
+	cycle, err := Matrix(3, 3, []float64{0, 1, 0, 0, 0, 1, 1, 0, 0})
+	assert.Nil(err)
+	P, err = cycle.Pow(math.MinInt64)
+	assert.Nil(err)
+	assert.Equal(cycle.Elements, P.Elements)
+	P, err = cycle.Pow(math.MinInt64 + 1)
+	assert.Nil(err)
+	assert.Equal(cycle.Transpose().Elements, P.Elements)
+}
+
+func BenchmarkPow(b *testing.B) {
+	m, _ := Matrix(3, 3, []float64{0.5, 0.25, 0.25, 0.1, 0.8, 0.1, 0.3, 0.3, 0.4})
+	for n := 0; n < b.N; n++ {
+		_, _ = m.Pow(100)
+	}
+}
+
+func TestPolyval(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{1, 2, 0, 0, 1, -1, 3, 0, 2})
+	assert.Nil(err)
+	eye, _ := Eye(3, 3)
+
+	for d := 0; d <= 12; d++ {
+		coeffs := make([]float64, d+1)
+		for i := range coeffs {
+			coeffs[i] = float64(i%4) - 1.5
+		}
+
+		// Horner's method as the reference.
+		expected, _ := Zeros(3, 3)
+		for _, c := range coeffs {
+			expected, _ = expected.Multiply(a)
+			expected, _ = expected.Add(eye.ScalarMultiply(c))
+		}
+
+		P, err := a.Polyval(coeffs)
+		assert.Nil(err)
+		assert.InDeltaSlice(expected.Elements, P.Elements, 1e-6)
+	}
+
+	P, err := a.Polyval(nil)
+	assert.Nil(err)
+	zero, _ := Zeros(3, 3)
+	assert.Equal(zero.Elements, P.Elements)
+
+	scalar, _ := Matrix(1, 1, []float64{2})
+	P, err = scalar.Polyval([]float64{1, -3, 2})
+	assert.Nil(err)
+	assert.Equal([]float64{0}, P.Elements)
+
+	wide, _ := Ones(2, 3)
+	_, err = wide.Polyval([]float64{1})
+	assert.NotNil(err)
+}
+
+func BenchmarkPolyval(b *testing.B) {
+	m, _ := Matrix(3, 3, []float64{0.5, 0.25, 0.25, 0.1, 0.8, 0.1, 0.3, 0.3, 0.4})
+	coeffs := make([]float64, 17)
+	for i := range coeffs {
+		coeffs[i] = 1 / float64(i+1)
+	}
+	for n := 0; n < b.N; n++ {
+		_, _ = m.Polyval(coeffs)
+	}
+}
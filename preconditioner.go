@@ -0,0 +1,265 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// csrOf returns the CSR form of any matrix, converting only when it is not already CSR.
+func csrOf(A Interface) *CSR {
+	switch a := A.(type) {
+	case *CSR:
+		return a
+	// Every matrix type in this package has a CSR method that converts in time proportional to its storage.
+	case interface{ CSR() *CSR }:
+		return a.CSR()
+	}
+
+	// Without a CSR method the elements are read one at a time, which takes O(rows*columns) time but never allocates a dense copy.
+	rows, columns := A.Dims()
+	a := &CSR{Rows: rows, Columns: columns, RowPointers: make([]int, rows+1)}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			if value, _ := A.GetValue(i, j); value != 0 {
+				a.ColumnIndices = append(a.ColumnIndices, j)
+				a.Values = append(a.Values, value)
+			}
+		}
+		a.RowPointers[i+1] = len(a.Values)
+	}
+	return a
+}
+
+// squareCSR returns the CSR form of A, or an error if it is not square.
+func squareCSR(A Interface) (*CSR, error) {
+	if rows, columns := A.Dims(); rows != columns {
+		return nil, errors.New("Not a square matrix")
+	}
+	return csrOf(A), nil
+}
+
+// Jacobi is the diagonal preconditioner M = diag(A), the cheapest choice and often enough for diagonally dominant systems.
+type Jacobi struct {
+	inverse []float64
+}
+
+// NewJacobi will return the Jacobi preconditioner for a square matrix, which must have a nonzero diagonal.
+func NewJacobi(A Interface) (*Jacobi, error) {
+	a, err := squareCSR(A)
+	if err != nil {
+		return nil, err
+	}
+
+	inverse := make([]float64, a.Rows)
+	for i, p := range a.diagonal() {
+		if p < 0 || a.Values[p] == 0 {
+			return nil, errors.New("Matrix has a zero on the diagonal")
+		}
+		inverse[i] = 1 / a.Values[p]
+	}
+	return &Jacobi{inverse: inverse}, nil
+}
+
+// Precondition will write diag(A)^-1*r into z.
+func (p *Jacobi) Precondition(z, r []float64) {
+	for i, value := range r {
+		z[i] = value * p.inverse[i]
+	}
+}
+
+// SSOR is the symmetric successive over-relaxation preconditioner M = (D + w*L)*D^-1*(D + w*U)/(w*(2 - w)), where A = L + D + U.
+type SSOR struct {
+	a     *CSR
+	diag  []int
+	omega float64
+	work  []float64
+}
+
+// NewSSOR will return the SSOR preconditioner for a square matrix with relaxation factor omega strictly between 0 and 2.
+func NewSSOR(A Interface, omega float64) (*SSOR, error) {
+	a, err := squareCSR(A)
+	if err != nil {
+		return nil, err
+	}
+	// An omega of 1 gives the symmetric Gauss-Seidel preconditioner.
+	if !(omega > 0 && omega < 2) {
+		return nil, errors.New("Relaxation factor must be between 0 and 2")
+	}
+
+	diag := a.diagonal()
+	for _, p := range diag {
+		if p < 0 || a.Values[p] == 0 {
+			return nil, errors.New("Matrix has a zero on the diagonal")
+		}
+	}
+	return &SSOR{a: a, diag: diag, omega: omega, work: make([]float64, a.Rows)}, nil
+}
+
+// Precondition will write M^-1*r into z using scratch space, so one SSOR must not be shared between goroutines.
+func (p *SSOR) Precondition(z, r []float64) {
+	a, w, y := p.a, p.omega, p.work
+
+	// (D + w*L)*y = r
+	for i := 0; i < a.Rows; i++ {
+		sum := r[i]
+		for q := a.RowPointers[i]; q < p.diag[i]; q++ {
+			sum -= w * a.Values[q] * y[a.ColumnIndices[q]]
+		}
+		y[i] = sum / a.Values[p.diag[i]]
+	}
+
+	// (D + w*U)*z = D*y*w*(2 - w)
+	for i := a.Rows - 1; i >= 0; i-- {
+		d := a.Values[p.diag[i]]
+		sum := d * y[i] * w * (2 - w)
+		for q := p.diag[i] + 1; q < a.RowPointers[i+1]; q++ {
+			sum -= w * a.Values[q] * z[a.ColumnIndices[q]]
+		}
+		z[i] = sum / d
+	}
+}
+
+// IC0 is the zero fill incomplete Cholesky preconditioner M = L*L^T, where L has the same sparsity pattern as the lower triangle of A.
+type IC0 struct {
+	l    *CSR
+	diag []int
+	work []float64
+}
+
+// NewIC0 will return the incomplete Cholesky preconditioner of a symmetric positive definite matrix, or an error if the factorization breaks down.
+func NewIC0(A Interface) (*IC0, error) {
+	a := csrOf(A)
+	if !a.IsSymmetric() {
+		return nil, errors.New("Not a symmetric matrix")
+	}
+
+	l := a.band(-a.Rows, 0)
+	diag := l.diagonal()
+	n := a.Rows
+	row := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for q := l.RowPointers[i]; q < l.RowPointers[i+1]; q++ {
+			row[l.ColumnIndices[q]] = l.Values[q]
+		}
+
+		// L[i][k] = (A[i][k] - sum L[i][j]*L[k][j]) / L[k][k] over the pattern of row k.
+		for q := l.RowPointers[i]; q < diag[i]; q++ {
+			k := l.ColumnIndices[q]
+			sum := row[k]
+			for t := l.RowPointers[k]; t < diag[k]; t++ {
+				sum -= row[l.ColumnIndices[t]] * l.Values[t]
+			}
+			row[k] = sum / l.Values[diag[k]]
+		}
+
+		d := row[i]
+		for q := l.RowPointers[i]; q < diag[i]; q++ {
+			d -= row[l.ColumnIndices[q]] * row[l.ColumnIndices[q]]
+		}
+		// This can happen even for a positive definite matrix, a diagonal shift or SSOR is the usual fallback.
+		if d <= 0 {
+			return nil, errors.New("Incomplete Cholesky factorization broke down")
+		}
+		row[i] = math.Sqrt(d)
+
+		for q := l.RowPointers[i]; q < l.RowPointers[i+1]; q++ {
+			l.Values[q] = row[l.ColumnIndices[q]]
+			row[l.ColumnIndices[q]] = 0
+		}
+	}
+	return &IC0{l: l, diag: diag, work: make([]float64, n)}, nil
+}
+
+// Precondition will write (L*L^T)^-1*r into z using scratch space, so one IC0 must not be shared between goroutines.
+func (p *IC0) Precondition(z, r []float64) {
+	l, y := p.l, p.work
+
+	for i := 0; i < l.Rows; i++ {
+		sum := r[i]
+		for q := l.RowPointers[i]; q < p.diag[i]; q++ {
+			sum -= l.Values[q] * y[l.ColumnIndices[q]]
+		}
+		y[i] = sum / l.Values[p.diag[i]]
+	}
+
+	// L^T is solved by columns, using the rows of L.
+	copy(z, y)
+	for i := l.Rows - 1; i >= 0; i-- {
+		z[i] /= l.Values[p.diag[i]]
+		for q := l.RowPointers[i]; q < p.diag[i]; q++ {
+			z[l.ColumnIndices[q]] -= l.Values[q] * z[i]
+		}
+	}
+}
+
+// ILU0 is the zero fill incomplete LU preconditioner M = L*U, where L and U have the same sparsity pattern as A.
+type ILU0 struct {
+	lu   *CSR
+	diag []int
+	work []float64
+}
+
+// NewILU0 will return the incomplete LU preconditioner of a square matrix, or an error if a zero pivot appears.
+func NewILU0(A Interface) (*ILU0, error) {
+	a, err := squareCSR(A)
+	if err != nil {
+		return nil, err
+	}
+
+	lu := a.band(-a.Rows, a.Rows)
+	diag := lu.diagonal()
+	n := a.Rows
+	position := make([]int, n)
+	for i := range position {
+		position[i] = -1
+	}
+
+	for i := 0; i < n; i++ {
+		for q := lu.RowPointers[i]; q < lu.RowPointers[i+1]; q++ {
+			position[lu.ColumnIndices[q]] = q
+		}
+
+		for q := lu.RowPointers[i]; q < diag[i]; q++ {
+			k := lu.ColumnIndices[q]
+			pivot := lu.Values[diag[k]]
+			if pivot == 0 {
+				return nil, errors.New("Incomplete LU factorization broke down")
+			}
+			lu.Values[q] /= pivot
+			for t := diag[k] + 1; t < lu.RowPointers[k+1]; t++ {
+				if p := position[lu.ColumnIndices[t]]; p >= 0 {
+					lu.Values[p] -= lu.Values[q] * lu.Values[t]
+				}
+			}
+		}
+		if lu.Values[diag[i]] == 0 {
+			return nil, errors.New("Incomplete LU factorization broke down")
+		}
+
+		for q := lu.RowPointers[i]; q < lu.RowPointers[i+1]; q++ {
+			position[lu.ColumnIndices[q]] = -1
+		}
+	}
+	return &ILU0{lu: lu, diag: diag, work: make([]float64, n)}, nil
+}
+
+// Precondition will write (L*U)^-1*r into z using scratch space, so one ILU0 must not be shared between goroutines.
+func (p *ILU0) Precondition(z, r []float64) {
+	lu, y := p.lu, p.work
+
+	for i := 0; i < lu.Rows; i++ {
+		sum := r[i]
+		for q := lu.RowPointers[i]; q < p.diag[i]; q++ {
+			sum -= lu.Values[q] * y[lu.ColumnIndices[q]]
+		}
+		y[i] = sum
+	}
+
+	for i := lu.Rows - 1; i >= 0; i-- {
+		sum := y[i]
+		for q := p.diag[i] + 1; q < lu.RowPointers[i+1]; q++ {
+			sum -= lu.Values[q] * z[lu.ColumnIndices[q]]
+		}
+		z[i] = sum / lu.Values[p.diag[i]]
+	}
+}
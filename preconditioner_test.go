@@ -0,0 +1,119 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJacobi(t *testing.T) {
+	assert := assert.New(t)
+
+	A, _ := Matrix(2, 2, []float64{2, 1, 1, 4})
+	p, err := NewJacobi(A)
+	assert.Nil(err)
+	z := make([]float64, 2)
+	p.Precondition(z, []float64{1, 1})
+	assert.InDeltaSlice([]float64{0.5, 0.25}, z, 1e-15)
+
+	zero, _ := Matrix(2, 2, []float64{0, 1, 1, 4})
+	_, err = NewJacobi(zero)
+	assert.NotNil(err)
+}
+
+func TestSSOR(t *testing.T) {
+	assert := assert.New(t)
+
+	// With omega = 1 on a triangular matrix the sweeps are exact.
+	A, _ := Matrix(3, 3, []float64{2, 0, 0, 1, 3, 0, -1, 2, 4})
+	p, err := NewSSOR(A, 1)
+	assert.Nil(err)
+	expected, _ := A.TriangleSolve(&MatrixStruct{Rows: 3, Columns: 1, Capacity: 3, Elements: []float64{1, 2, 3}})
+	z := make([]float64, 3)
+	p.Precondition(z, []float64{1, 2, 3})
+	assert.InDeltaSlice(expected.Elements, z, 1e-15)
+
+	_, err = NewSSOR(A, 2)
+	assert.NotNil(err)
+}
+
+func TestIC0(t *testing.T) {
+	assert := assert.New(t)
+
+	// A tridiagonal matrix has no fill, so the incomplete factorization is the exact one.
+	A, _ := Matrix(3, 3, []float64{4, -1, 0, -1, 4, -1, 0, -1, 4})
+	p, err := NewIC0(A)
+	assert.Nil(err)
+	z := make([]float64, 3)
+	p.Precondition(z, []float64{3, 2, 3})
+	assert.InDeltaSlice([]float64{1, 1, 1}, z, 1e-15)
+
+	indefinite, _ := Matrix(2, 2, []float64{1, 2, 2, 1})
+	_, err = NewIC0(indefinite)
+	assert.NotNil(err)
+}
+
+func BenchmarkIC0(b *testing.B) {
+	A := poisson(10)
+	for n := 0; n < b.N; n++ {
+		_, _ = NewIC0(A)
+	}
+}
+
+func TestILU0(t *testing.T) {
+	assert := assert.New(t)
+
+	A, _ := Matrix(3, 3, []float64{4, -2, 0, -1, 4, -1, 0, -3, 4})
+	p, err := NewILU0(A)
+	assert.Nil(err)
+	z := make([]float64, 3)
+	p.Precondition(z, []float64{2, 2, 1})
+	assert.InDeltaSlice([]float64{1, 1, 1}, z, 1e-15)
+
+	zero, _ := Matrix(2, 2, []float64{0, 1, 1, 0})
+	_, err = NewILU0(zero)
+	assert.NotNil(err)
+}
+
+func BenchmarkILU0(b *testing.B) {
+	A := convectionDiffusion(10)
+	for n := 0; n < b.N; n++ {
+		_, _ = NewILU0(A)
+	}
+}
+
+// scaling is a minimal Interface without a CSR method, standing in for user defined matrix types.
+type scaling []float64
+
+func (s scaling) Dims() (int, int) { return len(s), len(s) }
+func (s scaling) GetValue(y, x int) (float64, error) {
+	if y == x {
+		return s[y], nil
+	}
+	return 0, nil
+}
+func (s scaling) MulVec(dst, x []float64) {
+	for i, value := range s {
+		dst[i] = value * x[i]
+	}
+}
+func (s scaling) T() Interface { return s }
+func (s scaling) Dense() *MatrixStruct {
+	panic("csrOf must not make a dense copy")
+}
+
+func TestCSROf(t *testing.T) {
+	assert := assert.New(t)
+
+	A := poisson(3)
+	symmetric, _ := A.Symmetric()
+	L, _ := symmetric.Cholesky()
+	for _, m := range []Interface{A, A.CSC(), A.COO(), A.Banded(), spline(6), symmetric, L, L.Transpose()} {
+		assert.Equal(m.Dense().CSR(), csrOf(m))
+	}
+
+	jacobi, err := NewJacobi(scaling{1, 2, 4})
+	assert.Nil(err)
+	z := make([]float64, 3)
+	jacobi.Precondition(z, []float64{1, 1, 1})
+	assert.InDeltaSlice([]float64{1, 0.5, 0.25}, z, 1e-15)
+}
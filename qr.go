@@ -0,0 +1,289 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// QRPivot will return the rank revealing QR decomposition with column pivoting, m*P = Q*R, using Householder reflections.
+func (m MatrixStruct) QRPivot() (Q, R, P *MatrixStruct) {
+	rows, cols := m.Rows, m.Columns
+	R = m.Clone()
+	r := R.Elements
+	Q, _ = Eye(rows, rows)
+	perm := make([]int, cols)
+	for i := range perm {
+		perm[i] = i
+	}
+
+	// norms are downdated after every step as in LAPACK's xGEQP3, and reference holds the
+	// last norm computed from scratch so that cancellation can be detected.
+	norms := make([]float64, cols)
+	reference := make([]float64, cols)
+	for j := 0; j < cols; j++ {
+		norms[j] = columnNorm(R, j, 0)
+		reference[j] = norms[j]
+	}
+	tol := math.Sqrt(epsilon)
+
+	x := make([]float64, rows)
+	for k := 0; k < m.shortestDimension(); k++ {
+		// Moving the largest remaining column forward keeps the diagonal of R non-increasing.
+		pivot := k
+		for j := k + 1; j < cols; j++ {
+			if norms[j] > norms[pivot] {
+				pivot = j
+			}
+		}
+
+		if pivot != k {
+			for i := 0; i < rows; i++ {
+				r[i*cols+k], r[i*cols+pivot] = r[i*cols+pivot], r[i*cols+k]
+			}
+			perm[k], perm[pivot] = perm[pivot], perm[k]
+			norms[pivot], reference[pivot] = norms[k], reference[k]
+		}
+
+		for i := k; i < rows; i++ {
+			x[i-k] = r[i*cols+k]
+		}
+		v, beta, alpha := householder(x[:rows-k])
+		if beta != 0 {
+			applyHouseholderLeft(R, v, beta, k, k+1)
+			applyHouseholderRight(Q, v, beta, 0, k)
+			r[k*cols+k] = alpha
+			for i := k + 1; i < rows; i++ {
+				r[i*cols+k] = 0
+			}
+		}
+
+		// Removing row k from a column leaves norm^2 - r[k][j]^2. When most of the norm has cancelled the update loses accuracy, so the norm is recomputed instead.
+		for j := k + 1; j < cols; j++ {
+			if norms[j] == 0 {
+				continue
+			}
+			ratio := math.Abs(r[k*cols+j]) / norms[j]
+			remaining := math.Max(0, (1+ratio)*(1-ratio))
+			scaled := norms[j] / reference[j]
+			if remaining*scaled*scaled <= tol {
+				norms[j] = columnNorm(R, j, k+1)
+				reference[j] = norms[j]
+			} else {
+				norms[j] *= math.Sqrt(remaining)
+			}
+		}
+	}
+
+	P, _ = Zeros(cols, cols)
+	for j, i := range perm {
+		P.Elements[i*cols+j] = 1
+	}
+	return
+}
+
+// columnNorm returns the Euclidean norm of column j of m from row start down.
+func columnNorm(m *MatrixStruct, j, start int) float64 {
+	norm := float64(0)
+	for i := start; i < m.Rows; i++ {
+		norm = math.Hypot(norm, m.Elements[i*m.Columns+j])
+	}
+	return norm
+}
+
+// householder returns the vector v and scalar beta such that (I - beta*v*v^T)*x = alpha*e1.
+func householder(x []float64) (v []float64, beta, alpha float64) {
+	v = make([]float64, len(x))
+	copy(v, x)
+
+	norm := float64(0)
+	for _, value := range x {
+		norm = math.Hypot(norm, value)
+	}
+	// A zero vector needs no reflection.
+	if norm == 0 {
+		return v, 0, 0
+	}
+
+	// alpha takes the opposite sign to x[0] to avoid cancellation.
+	alpha = -norm
+	if x[0] < 0 {
+		alpha = norm
+	}
+	v[0] -= alpha
+
+	vv := float64(0)
+	for _, value := range v {
+		vv += value * value
+	}
+	return v, 2 / vv, alpha
+}
+
+// applyHouseholderLeft replaces the len(v) rows of m starting at row and column with (I - beta*v*v^T)*block.
+func applyHouseholderLeft(m *MatrixStruct, v []float64, beta float64, row, column int) {
+	for j := column; j < m.Columns; j++ {
+		dot := float64(0)
+		for i, value := range v {
+			dot += value * m.Elements[(row+i)*m.Columns+j]
+		}
+		dot *= beta
+		for i, value := range v {
+			m.Elements[(row+i)*m.Columns+j] -= dot * value
+		}
+	}
+}
+
+// applyHouseholderRight replaces the len(v) columns of m starting at row and column with block*(I - beta*v*v^T).
+func applyHouseholderRight(m *MatrixStruct, v []float64, beta float64, row, column int) {
+	for i := row; i < m.Rows; i++ {
+		dot := float64(0)
+		for j, value := range v {
+			dot += value * m.Elements[i*m.Columns+column+j]
+		}
+		dot *= beta
+		for j, value := range v {
+			m.Elements[i*m.Columns+column+j] -= dot * value
+		}
+	}
+}
+
+// HouseholderQR is a compact QR decomposition that stores the Householder vectors below the diagonal and R on and above it.
+type HouseholderQR struct {
+	// The first element of each Householder vector is implied to be one.
+	qr  *MatrixStruct
+	tau []float64
+}
+
+// QRCompact will return the QR decomposition of the matrix in compact Householder form.
+func (m MatrixStruct) QRCompact() *HouseholderQR {
+	rows, cols := m.Rows, m.Columns
+	qr := m.Clone()
+	a := qr.Elements
+	k := m.shortestDimension()
+	tau := make([]float64, k)
+
+	x := make([]float64, rows)
+	for j := 0; j < k; j++ {
+		for i := j; i < rows; i++ {
+			x[i-j] = a[i*cols+j]
+		}
+		v, beta, alpha := householder(x[:rows-j])
+		if beta == 0 {
+			continue
+		}
+
+		applyHouseholderLeft(qr, v, beta, j, j+1)
+
+		// Scale v so its first element is one, which lets it be stored below the diagonal.
+		tau[j] = beta * v[0] * v[0]
+		a[j*cols+j] = alpha
+		for i := j + 1; i < rows; i++ {
+			a[i*cols+j] = v[i-j] / v[0]
+		}
+	}
+
+	return &HouseholderQR{qr: qr, tau: tau}
+}
+
+// QREconomy will return the MxN matrix Q and NxN matrix R of the economy size QR decomposition of a matrix with at least as many rows as columns.
+func (m MatrixStruct) QREconomy() (Q, R *MatrixStruct, err error) {
+	if m.Rows < m.Columns {
+		return nil, nil, errors.New("Matrix must have at least as many rows as columns")
+	}
+
+	f := m.QRCompact()
+	return f.Q(true), f.R(true), nil
+}
+
+// R returns the upper triangular factor, or only its leading square block if economy is true.
+func (f *HouseholderQR) R(economy bool) *MatrixStruct {
+	rows, cols := f.qr.Rows, f.qr.Columns
+	if economy && rows > cols {
+		rows = cols
+	}
+
+	R, _ := Zeros(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := i; j < cols; j++ {
+			R.Elements[i*cols+j] = f.qr.Elements[i*cols+j]
+		}
+	}
+	return R
+}
+
+// Q forms the orthogonal factor, or only its first N columns if economy is true.
+func (f *HouseholderQR) Q(economy bool) *MatrixStruct {
+	columns := f.qr.Rows
+	if economy && f.qr.Rows > f.qr.Columns {
+		columns = f.qr.Columns
+	}
+
+	// Q is only formed here, by applying the reflections to the identity.
+	Q, _ := Eye(f.qr.Rows, columns)
+	f.applyQ(Q, false)
+	return Q
+}
+
+// ApplyQ will return Q*b where b has as many rows as the factored matrix.
+func (f *HouseholderQR) ApplyQ(b *MatrixStruct) (*MatrixStruct, error) {
+	if b.Rows != f.qr.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	x := b.Clone()
+	f.applyQ(x, false)
+	return x, nil
+}
+
+// ApplyQT will return Q^T*b where b has as many rows as the factored matrix.
+func (f *HouseholderQR) ApplyQT(b *MatrixStruct) (*MatrixStruct, error) {
+	if b.Rows != f.qr.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	x := b.Clone()
+	f.applyQ(x, true)
+	return x, nil
+}
+
+// Solve will return the least squares solution of A*x = b using the compact factors of a matrix with at least as many rows as columns.
+func (f *HouseholderQR) Solve(b *MatrixStruct) (*MatrixStruct, error) {
+	if f.qr.Rows < f.qr.Columns {
+		return nil, errors.New("Matrix must have at least as many rows as columns")
+	}
+
+	c, err := f.ApplyQT(b)
+	if err != nil {
+		return nil, err
+	}
+
+	x, _ := Zeros(f.qr.Columns, b.Columns)
+	copy(x.Elements, c.Elements[:f.qr.Columns*b.Columns])
+	if err := backSubstitution(f.qr, x, false); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// applyQ overwrites x with Q*x, or Q^T*x if transpose is true.
+func (f *HouseholderQR) applyQ(x *MatrixStruct, transpose bool) {
+	rows, cols := f.qr.Rows, f.qr.Columns
+	k := len(f.tau)
+	v := make([]float64, rows)
+
+	// Q = H_0*H_1*...*H_k-1, so the reflections are applied in reverse order for Q and in order for Q^T.
+	for step := 0; step < k; step++ {
+		j := k - 1 - step
+		if transpose {
+			j = step
+		}
+		if f.tau[j] == 0 {
+			continue
+		}
+
+		v[0] = 1
+		for i := j + 1; i < rows; i++ {
+			v[i-j] = f.qr.Elements[i*cols+j]
+		}
+		applyHouseholderLeft(x, v[:rows-j], f.tau[j], j, 0)
+	}
+}
@@ -0,0 +1,148 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestQRPivot(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	assert.Nil(err)
+
+	Q, R, P := a.QRPivot()
+	assert.Equal(4, Q.Rows)
+	assert.Equal(4, Q.Columns)
+	assert.Equal(4, R.Rows)
+	assert.Equal(3, R.Columns)
+	assert.Equal(3, P.Rows)
+	assert.True(R.IsUpperTriangular())
+
+	AP, _ := a.Multiply(P)
+	QR, _ := Q.Multiply(R)
+	assert.InDeltaSlice(AP.Elements, QR.Elements, 1e-12)
+
+	QTQ, _ := Q.Transpose().Multiply(Q)
+	eye, _ := Eye(4, 4)
+	assert.InDeltaSlice(eye.Elements, QTQ.Elements, 1e-12)
+
+	for i := 1; i < 3; i++ {
+		assert.True(math.Abs(R.Elements[i*3+i]) <= math.Abs(R.Elements[(i-1)*3+i-1]))
+	}
+	assert.InDelta(0, R.Elements[8], 1e-12)
+
+	b, err := Matrix(3, 3, []float64{1, 0, 1, 1, 0, 1, 1, 0, 1})
+	assert.Nil(err)
+	Q, R, P = b.QRPivot()
+	BP, _ := b.Multiply(P)
+	QR, _ = Q.Multiply(R)
+	assert.InDeltaSlice(BP.Elements, QR.Elements, 1e-12)
+	assert.InDelta(0, R.Elements[4], 1e-12)
+	assert.InDelta(0, R.Elements[8], 1e-12)
+}
+
+func TestQRPivotNorms(t *testing.T) {
+	assert := assert.New(t)
+
+	// The last two columns nearly cancel against the first, which forces the downdated norms to be recomputed.
+	nearly, _ := Matrix(4, 3, []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1 + 1e-9})
+	for _, a := range []*MatrixStruct{trigMatrix(9, 6, 1), trigMatrix(6, 9, 2), nearly} {
+		Q, R, P := a.QRPivot()
+		AP, _ := a.Multiply(P)
+		QR, _ := Q.Multiply(R)
+		assert.InDeltaSlice(AP.Elements, QR.Elements, 1e-12)
+
+		// Each pivot is at least as large as the norm of every column still to be factored.
+		for k := 0; k < R.shortestDimension(); k++ {
+			for j := k + 1; j < R.Columns; j++ {
+				assert.True(math.Abs(R.Elements[k*R.Columns+k]) >= columnNorm(R, j, k)-1e-12)
+			}
+		}
+	}
+}
+
+func BenchmarkQRPivot(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = a.QRPivot()
+	}
+}
+
+func TestQRCompact(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(5, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 10, 1, 0, 1, 2, -1, 3})
+	assert.Nil(err)
+
+	f := a.QRCompact()
+
+	Q := f.Q(false)
+	R := f.R(false)
+	assert.Equal(5, Q.Rows)
+	assert.Equal(5, Q.Columns)
+	QR, _ := Q.Multiply(R)
+	assert.InDeltaSlice(a.Elements, QR.Elements, 1e-12)
+
+	QTQ, _ := Q.Transpose().Multiply(Q)
+	eye, _ := Eye(5, 5)
+	assert.InDeltaSlice(eye.Elements, QTQ.Elements, 1e-12)
+
+	b, err := Vector(1, 2, 3, 4, 5)
+	assert.Nil(err)
+
+	qtb, err := f.ApplyQT(b)
+	assert.Nil(err)
+	expected, _ := Q.Transpose().Multiply(b)
+	assert.InDeltaSlice(expected.Elements, qtb.Elements, 1e-12)
+
+	qb, err := f.ApplyQ(qtb)
+	assert.Nil(err)
+	assert.InDeltaSlice(b.Elements, qb.Elements, 1e-12)
+
+	x, err := f.Solve(b)
+	assert.Nil(err)
+	y, _, err := a.LeastSquares(b)
+	assert.Nil(err)
+	assert.InDeltaSlice(y.Elements, x.Elements, 1e-12)
+
+	c, err := Vector(1, 2)
+	assert.Nil(err)
+	_, err = f.ApplyQ(c)
+	assert.NotNil(err)
+	_, err = f.ApplyQT(c)
+	assert.NotNil(err)
+}
+
+func BenchmarkQRCompact(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	for n := 0; n < b.N; n++ {
+		_ = a.QRCompact()
+	}
+}
+
+func TestQREconomy(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(5, 2, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 1})
+	assert.Nil(err)
+
+	Q, R, err := a.QREconomy()
+	assert.Nil(err)
+	assert.Equal(5, Q.Rows)
+	assert.Equal(2, Q.Columns)
+	assert.Equal(2, R.Rows)
+	assert.Equal(2, R.Columns)
+	assert.True(R.IsUpperTriangular())
+
+	QR, _ := Q.Multiply(R)
+	assert.InDeltaSlice(a.Elements, QR.Elements, 1e-12)
+
+	QTQ, _ := Q.Transpose().Multiply(Q)
+	eye, _ := Eye(2, 2)
+	assert.InDeltaSlice(eye.Elements, QTQ.Elements, 1e-12)
+
+	_, _, err = a.Transpose().QREconomy()
+	assert.NotNil(err)
+}
@@ -0,0 +1,262 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+)
+
+// GeneralizedEigen will return the generalized eigenvalues alpha/beta of the pencil A - lambda*B using the QZ algorithm.
+func GeneralizedEigen(A, B *MatrixStruct) (alpha []complex128, beta []float64, err error) {
+	if !A.IsSquare() || !B.IsSquare() || A.Rows != B.Rows {
+		return nil, nil, errors.New("matrix dimensions do not agree")
+	}
+
+	H, T := hessenbergTriangular(A, B)
+	n := A.Rows
+	h := make([]complex128, n*n)
+	t := make([]complex128, n*n)
+	for i := range h {
+		h[i] = complex(H.Elements[i], 0)
+		t[i] = complex(T.Elements[i], 0)
+	}
+
+	alpha = make([]complex128, n)
+	beta = make([]float64, n)
+	if err := complexQZ(h, t, n, H.frobenius(), T.frobenius()); err != nil {
+		return nil, nil, err
+	}
+
+	// beta is made real and non-negative, so an infinite eigenvalue from a singular B has a beta of zero.
+	for i := 0; i < n; i++ {
+		a, b := h[i*n+i], t[i*n+i]
+		if scale := cmplx.Abs(b); scale != 0 {
+			a *= cmplx.Conj(b) / complex(scale, 0)
+			b = complex(scale, 0)
+		}
+		alpha[i] = a
+		beta[i] = real(b)
+	}
+	return alpha, beta, nil
+}
+
+// GeneralizedEigenSym will return the eigenvalues in ascending order and the B-orthonormal eigenvectors of A*x = lambda*B*x for symmetric A and positive definite B.
+func GeneralizedEigenSym(A, B *MatrixStruct) (values []float64, vectors *MatrixStruct, err error) {
+	if !A.IsSymmetric() {
+		return nil, nil, errors.New("Not a symmetric matrix")
+	}
+	if B.Rows != A.Rows {
+		return nil, nil, errors.New("matrix dimensions do not agree")
+	}
+
+	L, err := B.Cholesky()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// C = L^-1 * A * L^-T, which is symmetric because A is.
+	X := A.Clone()
+	if err := forwardSubstitution(L, X, false); err != nil {
+		return nil, nil, err
+	}
+	C := X.Transpose()
+	if err := forwardSubstitution(L, C, false); err != nil {
+		return nil, nil, err
+	}
+	n := A.Rows
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			mean := (C.Elements[i*n+j] + C.Elements[j*n+i]) / 2
+			C.Elements[i*n+j] = mean
+			C.Elements[j*n+i] = mean
+		}
+	}
+
+	values, Y, err := C.EigenSym()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// X = L^-T * Y, which is normalised so that X^T*B*X = I.
+	if err := backSubstitution(L.Transpose(), Y, false); err != nil {
+		return nil, nil, err
+	}
+	return values, Y, nil
+}
+
+// hessenbergTriangular reduces the pencil (A, B) to upper Hessenberg H = Q^T*A*Z and upper triangular T = Q^T*B*Z.
+func hessenbergTriangular(A, B *MatrixStruct) (H, T *MatrixStruct) {
+	n := A.Rows
+	// Q comes from a QR decomposition of B, then Givens rotations reduce H without losing the triangular T.
+	f := B.QRCompact()
+	H, _ = f.ApplyQT(A)
+	T = f.R(false)
+
+	for j := 0; j < n-2; j++ {
+		for i := n - 1; i > j+1; i-- {
+			g, _ := NewGivens(H.Elements[(i-1)*n+j], H.Elements[i*n+j], i-1, i)
+			g.ApplyLeft(H)
+			g.ApplyLeft(T)
+			H.Elements[i*n+j] = 0
+
+			// The row rotation filled in T[i][i-1], remove it with a column rotation.
+			g, _ = NewGivens(T.Elements[i*n+i], T.Elements[i*n+i-1], i, i-1)
+			g.ApplyRight(T)
+			g.ApplyRight(H)
+			T.Elements[i*n+i-1] = 0
+		}
+	}
+	return
+}
+
+// complexGivens returns c and s such that [c s; -conj(s) c] maps (a, b) to (r, 0), with c real.
+func complexGivens(a, b complex128) (float64, complex128) {
+	if b == 0 {
+		return 1, 0
+	}
+	if a == 0 {
+		return 0, cmplx.Conj(b) / complex(cmplx.Abs(b), 0)
+	}
+
+	absA := cmplx.Abs(a)
+	r := math.Hypot(absA, cmplx.Abs(b))
+	return absA / r, a / complex(absA, 0) * cmplx.Conj(b) / complex(r, 0)
+}
+
+// rotateRows applies the rotation to rows i and k of an n by n matrix between columns from and to inclusive.
+func rotateRows(m []complex128, n, i, k, from, to int, c float64, s complex128) {
+	for j := from; j <= to; j++ {
+		a, b := m[i*n+j], m[k*n+j]
+		m[i*n+j] = complex(c, 0)*a + s*b
+		m[k*n+j] = -cmplx.Conj(s)*a + complex(c, 0)*b
+	}
+}
+
+// rotateColumns applies the rotation to columns i and k of an n by n matrix between rows from and to inclusive.
+func rotateColumns(m []complex128, n, i, k, from, to int, c float64, s complex128) {
+	for r := from; r <= to; r++ {
+		a, b := m[r*n+i], m[r*n+k]
+		m[r*n+i] = complex(c, 0)*a + s*b
+		m[r*n+k] = -cmplx.Conj(s)*a + complex(c, 0)*b
+	}
+}
+
+// complexQZ reduces the Hessenberg-triangular pencil (h, t) to upper triangular form with single shift QZ iterations in complex arithmetic.
+func complexQZ(h, t []complex128, n int, normH, normT float64) error {
+	// Only the active diagonal block is updated, since the eigenvalues do not depend on the rest of the matrices.
+	atol := epsilon * normH
+	btol := epsilon * normT
+
+	iter := 0
+	total := 0
+	for ihi := n - 1; ihi >= 0; {
+		// Find the start of the active block.
+		l := ihi
+		for l > 0 {
+			if cmplx.Abs(h[l*n+l-1]) <= atol {
+				h[l*n+l-1] = 0
+				break
+			}
+			l--
+		}
+
+		if l == ihi {
+			ihi--
+			iter = 0
+			continue
+		}
+
+		// A negligible diagonal element of t means an infinite eigenvalue, chase it to the bottom of the block and deflate.
+		zero := -1
+		for j := l; j <= ihi; j++ {
+			if cmplx.Abs(t[j*n+j]) <= btol {
+				t[j*n+j] = 0
+				zero = j
+				break
+			}
+		}
+		if zero >= 0 {
+			for j := zero; j < ihi; j++ {
+				c, s := complexGivens(t[j*n+j+1], t[(j+1)*n+j+1])
+				rotateRows(t, n, j, j+1, j, ihi, c, s)
+				rotateRows(h, n, j, j+1, l, ihi, c, s)
+				t[(j+1)*n+j+1] = 0
+
+				if j > l {
+					c, s = complexGivens(h[(j+1)*n+j], h[(j+1)*n+j-1])
+					rotateColumns(h, n, j, j-1, l, ihi, c, s)
+					rotateColumns(t, n, j, j-1, l, ihi, c, s)
+					h[(j+1)*n+j-1] = 0
+				}
+			}
+
+			c, s := complexGivens(h[ihi*n+ihi], h[ihi*n+ihi-1])
+			rotateColumns(h, n, ihi, ihi-1, l, ihi, c, s)
+			rotateColumns(t, n, ihi, ihi-1, l, ihi, c, s)
+			h[ihi*n+ihi-1] = 0
+			ihi--
+			iter = 0
+			continue
+		}
+
+		total++
+		if total > eigenMaxIterations*n {
+			return errors.New("QZ iteration did not converge")
+		}
+		iter++
+
+		shift := qzShift(h, t, n, ihi)
+		if iter%10 == 0 {
+			// An exceptional shift breaks the symmetry of cycles that the Wilkinson shift can fall into.
+			shift = complex(cmplx.Abs(h[ihi*n+ihi-1])/cmplx.Abs(t[(ihi-1)*n+ihi-1]), 0) + h[ihi*n+ihi]/t[ihi*n+ihi]
+		}
+
+		// Single shift QZ sweep, chasing the bulge from the top of the block to the bottom.
+		x := h[l*n+l] - shift*t[l*n+l]
+		y := h[(l+1)*n+l]
+		for k := l; k < ihi; k++ {
+			c, s := complexGivens(x, y)
+			rotateRows(h, n, k, k+1, int(math.Max(float64(k-1), float64(l))), ihi, c, s)
+			rotateRows(t, n, k, k+1, k, ihi, c, s)
+			if k > l {
+				h[(k+1)*n+k-1] = 0
+			}
+
+			c, s = complexGivens(t[(k+1)*n+k+1], t[(k+1)*n+k])
+			bottom := int(math.Min(float64(k+2), float64(ihi)))
+			rotateColumns(h, n, k+1, k, l, bottom, c, s)
+			rotateColumns(t, n, k+1, k, l, k+1, c, s)
+			t[(k+1)*n+k] = 0
+
+			if k+1 < ihi {
+				x = h[(k+1)*n+k]
+				y = h[(k+2)*n+k]
+			}
+		}
+	}
+
+	return nil
+}
+
+// qzShift returns the generalised Wilkinson shift for the trailing 2x2 pencil of the active block.
+func qzShift(h, t []complex128, n, ihi int) complex128 {
+	a11, a12 := h[(ihi-1)*n+ihi-1], h[(ihi-1)*n+ihi]
+	a21, a22 := h[ihi*n+ihi-1], h[ihi*n+ihi]
+	b11, b12 := t[(ihi-1)*n+ihi-1], t[(ihi-1)*n+ihi]
+	b22 := t[ihi*n+ihi]
+
+	// det(A - lambda*B) = qa*lambda^2 + qb*lambda + qc for the 2x2 blocks.
+	qa := b11 * b22
+	qb := -(a11*b22 + a22*b11 - a21*b12)
+	qc := a11*a22 - a12*a21
+
+	// The root closest to the last diagonal ratio is used as the shift.
+	target := a22 / b22
+	disc := cmplx.Sqrt(qb*qb - 4*qa*qc)
+	r1 := (-qb + disc) / (2 * qa)
+	r2 := (-qb - disc) / (2 * qa)
+	if cmplx.Abs(r1-target) < cmplx.Abs(r2-target) {
+		return r1
+	}
+	return r2
+}
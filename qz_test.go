@@ -0,0 +1,161 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+)
+
+func TestGeneralizedEigen(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(5, 5, []float64{
+		1, 2, 0, -1, 3,
+		-2, 1, 4, 0, 1,
+		0, -3, 2, 1, 0,
+		5, 0, -1, 3, 2,
+		1, 1, 1, -4, 0,
+	})
+	assert.Nil(err)
+	eye, _ := Eye(5, 5)
+
+	alpha, beta, err := GeneralizedEigen(a, eye)
+	assert.Nil(err)
+	values, err := a.Eigen()
+	assert.Nil(err)
+
+	for _, value := range values {
+		found := false
+		for i := range alpha {
+			if cmplx.Abs(alpha[i]/complex(beta[i], 0)-value) < 1e-9 {
+				found = true
+			}
+		}
+		assert.True(found)
+	}
+
+	b, err := Matrix(2, 2, []float64{1, 2, 3, 4})
+	assert.Nil(err)
+	c, err := Matrix(2, 2, []float64{1, 0, 0, 0})
+	assert.Nil(err)
+
+	alpha, beta, err = GeneralizedEigen(b, c)
+	assert.Nil(err)
+	infinite, finite := 0, 0
+	for i := range alpha {
+		if beta[i] < 1e-12 {
+			infinite++
+			assert.True(cmplx.Abs(alpha[i]) > 1e-6)
+		} else {
+			finite++
+			assert.InDelta(-0.5, real(alpha[i])/beta[i], 1e-12)
+		}
+	}
+	assert.Equal(1, infinite)
+	assert.Equal(1, finite)
+
+	d, err := Matrix(4, 4, []float64{4, 1, 0, 2, 1, 3, 1, 0, 0, 1, 2, 1, 2, 0, 1, 5})
+	assert.Nil(err)
+	e, err := Matrix(4, 4, []float64{2, 0, 1, 0, 1, 1, 0, 0, 0, 3, 1, 1, 1, 0, 0, 2})
+	assert.Nil(err)
+
+	alpha, beta, err = GeneralizedEigen(d, e)
+	assert.Nil(err)
+	assert.Len(alpha, 4)
+	for i := range alpha {
+		lambda := alpha[i] / complex(beta[i], 0)
+		det := complexDet(d, e, lambda)
+		assert.InDelta(0, cmplx.Abs(det), 1e-8*math.Max(1, cmplx.Abs(lambda)*cmplx.Abs(lambda)*cmplx.Abs(lambda)*cmplx.Abs(lambda)))
+	}
+
+	f, err := Matrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	_, _, err = GeneralizedEigen(f, e)
+	assert.NotNil(err)
+}
+
+// complexDet evaluates det(A - lambda*B) by Gaussian elimination in complex arithmetic.
+func complexDet(A, B *MatrixStruct, lambda complex128) complex128 {
+	n := A.Rows
+	m := make([]complex128, n*n)
+	for i := range m {
+		m[i] = complex(A.Elements[i], 0) - lambda*complex(B.Elements[i], 0)
+	}
+
+	det := complex128(1)
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if cmplx.Abs(m[i*n+k]) > cmplx.Abs(m[p*n+k]) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				m[k*n+j], m[p*n+j] = m[p*n+j], m[k*n+j]
+			}
+			det = -det
+		}
+		if m[k*n+k] == 0 {
+			return 0
+		}
+		det *= m[k*n+k]
+		for i := k + 1; i < n; i++ {
+			l := m[i*n+k] / m[k*n+k]
+			for j := k; j < n; j++ {
+				m[i*n+j] -= l * m[k*n+j]
+			}
+		}
+	}
+	return det
+}
+
+func BenchmarkGeneralizedEigen(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{4, 1, 0, 2, 1, 3, 1, 0, 0, 1, 2, 1, 2, 0, 1, 5})
+	c, _ := Matrix(4, 4, []float64{2, 0, 1, 0, 1, 1, 0, 0, 0, 3, 1, 1, 1, 0, 0, 2})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = GeneralizedEigen(a, c)
+	}
+}
+
+func TestGeneralizedEigenSym(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{2, -1, 0, -1, 2, -1, 0, -1, 2})
+	assert.Nil(err)
+	b, err := Matrix(3, 3, []float64{4, 1, 0, 1, 4, 1, 0, 1, 4})
+	assert.Nil(err)
+
+	values, X, err := GeneralizedEigenSym(a, b)
+	assert.Nil(err)
+	assert.True(sort.Float64sAreSorted(values))
+
+	alpha, beta, err := GeneralizedEigen(a, b)
+	assert.Nil(err)
+	expected := make([]float64, 3)
+	for i := range alpha {
+		expected[i] = real(alpha[i]) / beta[i]
+	}
+	sort.Float64s(expected)
+	assert.InDeltaSlice(expected, values, 1e-12)
+
+	XTBX, _ := X.Transpose().Multiply(b)
+	XTBX, _ = XTBX.Multiply(X)
+	eye, _ := Eye(3, 3)
+	assert.InDeltaSlice(eye.Elements, XTBX.Elements, 1e-12)
+
+	AX, _ := a.Multiply(X)
+	BX, _ := b.Multiply(X)
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			assert.InDelta(values[j]*BX.Elements[i*3+j], AX.Elements[i*3+j], 1e-12)
+		}
+	}
+
+	c, err := Matrix(3, 3, []float64{1, 2, 0, 2, 1, 0, 0, 0, 1})
+	assert.Nil(err)
+	_, _, err = GeneralizedEigenSym(a, c)
+	assert.NotNil(err)
+}
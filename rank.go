@@ -0,0 +1,89 @@
+package matrix
+
+import "math"
+
+// DefaultTolerance will return the threshold below which singular values are considered to be zero, or an error if the SVD does not converge.
+func (m MatrixStruct) DefaultTolerance() (float64, error) {
+	s, err := m.SingularValues()
+	if err != nil {
+		return 0, err
+	}
+	return rankTolerance(m.Rows, m.Columns, s), nil
+}
+
+// Rank will return the number of singular values larger than tol, using the default tolerance if tol is not positive.
+func (m MatrixStruct) Rank(tol float64) (int, error) {
+	s, err := m.SingularValues()
+	if err != nil {
+		return 0, err
+	}
+	return countAbove(s, chooseTolerance(m, s, tol)), nil
+}
+
+// NullSpace will return a matrix whose columns are an orthonormal basis for the null space of the matrix.
+func (m MatrixStruct) NullSpace(tol float64) (*MatrixStruct, error) {
+	_, s, v, err := m.svd(true)
+	if err != nil {
+		return nil, err
+	}
+	// A matrix with full column rank returns a basis with no columns.
+	r := countAbove(s, chooseTolerance(m, s, tol))
+	return columnRange(completeBasis(v, m.Columns), r, m.Columns), nil
+}
+
+// ColumnSpace will return a matrix whose columns are an orthonormal basis for the range of the matrix.
+func (m MatrixStruct) ColumnSpace(tol float64) (*MatrixStruct, error) {
+	u, s, _, err := m.svd(true)
+	if err != nil {
+		return nil, err
+	}
+	r := countAbove(s, chooseTolerance(m, s, tol))
+	return columnRange(u, 0, r), nil
+}
+
+// RowSpace will return a matrix whose columns are an orthonormal basis for the space spanned by the rows of the matrix.
+func (m MatrixStruct) RowSpace(tol float64) (*MatrixStruct, error) {
+	_, s, v, err := m.svd(true)
+	if err != nil {
+		return nil, err
+	}
+	r := countAbove(s, chooseTolerance(m, s, tol))
+	return columnRange(v, 0, r), nil
+}
+
+// rankTolerance returns the threshold below which singular values are treated as zero, max(rows, columns) * eps * largest singular value.
+func rankTolerance(rows, columns int, s []float64) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+	return math.Max(float64(rows), float64(columns)) * epsilon * s[0]
+}
+
+// chooseTolerance returns tol, or the default tolerance if tol is not positive.
+func chooseTolerance(m MatrixStruct, s []float64, tol float64) float64 {
+	if tol > 0 {
+		return tol
+	}
+	return rankTolerance(m.Rows, m.Columns, s)
+}
+
+func countAbove(s []float64, tol float64) int {
+	count := 0
+	for _, value := range s {
+		if value > tol {
+			count++
+		}
+	}
+	return count
+}
+
+// columnRange returns the columns from start up to but not including end.
+func columnRange(m *MatrixStruct, start, end int) *MatrixStruct {
+	// Unlike Matrix this allows no columns, which represents an empty basis.
+	columns := end - start
+	elements := make([]float64, m.Rows*columns)
+	for i := 0; i < m.Rows; i++ {
+		copy(elements[i*columns:(i+1)*columns], m.Elements[i*m.Columns+start:i*m.Columns+end])
+	}
+	return &MatrixStruct{Rows: m.Rows, Columns: columns, Capacity: m.Rows * columns, Elements: elements}
+}
@@ -0,0 +1,131 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	assert.Nil(err)
+	rank, err := a.Rank(0)
+	assert.Nil(err)
+	assert.Equal(2, rank)
+	rank, _ = a.Rank(5)
+	assert.Equal(1, rank)
+
+	b, err := Eye(3, 3)
+	assert.Nil(err)
+	rank, _ = b.Rank(0)
+	assert.Equal(3, rank)
+
+	c, err := Zeros(2, 2)
+	assert.Nil(err)
+	rank, _ = c.Rank(0)
+	assert.Equal(0, rank)
+
+	d, err := Matrix(2, 2, []float64{1, 0, 0, 1e-14})
+	assert.Nil(err)
+	rank, _ = d.Rank(0)
+	assert.Equal(2, rank)
+	rank, _ = d.Rank(1e-10)
+	assert.Equal(1, rank)
+}
+
+func BenchmarkRank(b *testing.B) {
+	a, _ := Matrix(4, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	for n := 0; n < b.N; n++ {
+		_, _ = a.Rank(0)
+	}
+}
+
+func TestNullSpace(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(2, 3, []float64{1, 2, 3, 2, 4, 6})
+	assert.Nil(err)
+
+	N, err := a.NullSpace(0)
+	assert.Nil(err)
+	assert.Equal(3, N.Rows)
+	assert.Equal(2, N.Columns)
+
+	AN, err := a.Multiply(N)
+	assert.Nil(err)
+	assert.InDeltaSlice(make([]float64, 4), AN.Elements, 1e-12)
+
+	NTN, _ := N.Transpose().Multiply(N)
+	eye, _ := Eye(2, 2)
+	assert.InDeltaSlice(eye.Elements, NTN.Elements, 1e-12)
+
+	b, err := Eye(2, 2)
+	assert.Nil(err)
+	N, _ = b.NullSpace(0)
+	assert.Equal(0, N.Columns)
+}
+
+func TestColumnSpace(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 2, []float64{1, 2, 2, 4, 3, 6})
+	assert.Nil(err)
+
+	C, err := a.ColumnSpace(0)
+	assert.Nil(err)
+	assert.Equal(3, C.Rows)
+	assert.Equal(1, C.Columns)
+
+	scale := C.Elements[0]
+	assert.InDelta(1/(scale*scale), 14, 1e-12)
+	assert.InDeltaSlice([]float64{scale, 2 * scale, 3 * scale}, C.Elements, 1e-12)
+}
+
+func TestRowSpace(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 2, []float64{1, 2, 2, 4, 3, 6})
+	assert.Nil(err)
+
+	R, err := a.RowSpace(0)
+	assert.Nil(err)
+	assert.Equal(2, R.Rows)
+	assert.Equal(1, R.Columns)
+
+	scale := R.Elements[0]
+	assert.InDelta(1/(scale*scale), 5, 1e-12)
+	assert.InDeltaSlice([]float64{scale, 2 * scale}, R.Elements, 1e-12)
+}
+
+func TestDefaultTolerance(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(2, 2, []float64{3, 0, 0, -4})
+	assert.Nil(err)
+	tol, err := a.DefaultTolerance()
+	assert.Nil(err)
+	assert.InDelta(2*epsilon*4, tol, 1e-30)
+}
+
+func TestRankNotConverged(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 2, []float64{1, math.NaN(), 3, 4, 5, 6})
+	assert.Nil(err)
+
+	_, err = a.DefaultTolerance()
+	assert.EqualError(err, "Singular value decomposition did not converge")
+	_, err = a.Rank(0)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+	N, err := a.NullSpace(0)
+	assert.Nil(N)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+	C, err := a.ColumnSpace(0)
+	assert.Nil(C)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+	R, err := a.RowSpace(0)
+	assert.Nil(R)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+}
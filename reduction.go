@@ -0,0 +1,73 @@
+package matrix
+
+import "errors"
+
+// Hessenberg will return the upper Hessenberg matrix H and the orthogonal matrix Q that satisfy m = Q*H*Q^T.
+func (m MatrixStruct) Hessenberg() (H, Q *MatrixStruct, err error) {
+	if !m.IsSquare() {
+		return nil, nil, errors.New("Not a square matrix")
+	}
+
+	H, Q = m.hessenberg()
+	return H, Q, nil
+}
+
+// Tridiagonalize will return the symmetric tridiagonal matrix T and the orthogonal matrix Q that satisfy m = Q*T*Q^T. The matrix must be symmetric.
+func (m MatrixStruct) Tridiagonalize() (T, Q *MatrixStruct, err error) {
+	if !m.IsSymmetric() {
+		return nil, nil, errors.New("Not a symmetric matrix")
+	}
+
+	Q, d, e := m.tridiagonal()
+	n := m.Rows
+	T, _ = Zeros(n, n)
+	for i := 0; i < n; i++ {
+		T.Elements[i*n+i] = d[i]
+		if i > 0 {
+			T.Elements[i*n+i-1] = e[i]
+			T.Elements[(i-1)*n+i] = e[i]
+		}
+	}
+	return T, Q, nil
+}
+
+// Bidiagonalize will return the orthogonal matrices U and V and the upper bidiagonal matrix B that satisfy m = U*B*V^T.
+func (m MatrixStruct) Bidiagonalize() (U, B, V *MatrixStruct) {
+	rows, cols := m.Rows, m.Columns
+	B = m.Clone()
+	b := B.Elements
+	U, _ = Eye(rows, rows)
+	V, _ = Eye(cols, cols)
+
+	// Reflections alternate from the left and right, which keeps the singular values of m.
+	x := make([]float64, rows+cols)
+	for k := 0; k < m.shortestDimension(); k++ {
+		for i := k; i < rows; i++ {
+			x[i-k] = b[i*cols+k]
+		}
+		if v, beta, alpha := householder(x[:rows-k]); beta != 0 {
+			applyHouseholderLeft(B, v, beta, k, k)
+			applyHouseholderRight(U, v, beta, 0, k)
+			b[k*cols+k] = alpha
+			for i := k + 1; i < rows; i++ {
+				b[i*cols+k] = 0
+			}
+		}
+
+		if k+1 >= cols {
+			continue
+		}
+		for j := k + 1; j < cols; j++ {
+			x[j-k-1] = b[k*cols+j]
+		}
+		if v, beta, alpha := householder(x[:cols-k-1]); beta != 0 {
+			applyHouseholderRight(B, v, beta, k, k+1)
+			applyHouseholderRight(V, v, beta, 0, k+1)
+			b[k*cols+k+1] = alpha
+			for j := k + 2; j < cols; j++ {
+				b[k*cols+j] = 0
+			}
+		}
+	}
+	return
+}
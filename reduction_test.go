@@ -0,0 +1,96 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHessenberg(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	assert.Nil(err)
+
+	H, Q, err := a.Hessenberg()
+	assert.Nil(err)
+	for i := 2; i < 4; i++ {
+		for j := 0; j < i-1; j++ {
+			assert.Equal(float64(0), H.Elements[i*4+j])
+		}
+	}
+
+	QH, _ := Q.Multiply(H)
+	A, _ := QH.Multiply(Q.Transpose())
+	assert.InDeltaSlice(a.Elements, A.Elements, 1e-12)
+
+	QTQ, _ := Q.Transpose().Multiply(Q)
+	eye, _ := Eye(4, 4)
+	assert.InDeltaSlice(eye.Elements, QTQ.Elements, 1e-12)
+
+	b, err := Matrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	_, _, err = b.Hessenberg()
+	assert.NotNil(err)
+}
+
+func BenchmarkHessenberg(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = a.Hessenberg()
+	}
+}
+
+func TestTridiagonalize(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 4, []float64{4, 1, -2, 2, 1, 2, 0, 1, -2, 0, 3, -2, 2, 1, -2, -1})
+	assert.Nil(err)
+
+	T, Q, err := a.Tridiagonalize()
+	assert.Nil(err)
+	assert.True(T.IsSymmetric())
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if i-j > 1 || j-i > 1 {
+				assert.Equal(float64(0), T.Elements[i*4+j])
+			}
+		}
+	}
+
+	QT, _ := Q.Multiply(T)
+	A, _ := QT.Multiply(Q.Transpose())
+	assert.InDeltaSlice(a.Elements, A.Elements, 1e-12)
+
+	b, err := Matrix(2, 2, []float64{1, 2, 3, 4})
+	assert.Nil(err)
+	_, _, err = b.Tridiagonalize()
+	assert.NotNil(err)
+}
+
+func TestBidiagonalize(t *testing.T) {
+	assert := assert.New(t)
+
+	tall, err := Matrix(4, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 10, 1, 0, 1})
+	assert.Nil(err)
+	wide, err := Matrix(3, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 10, 1, 0, 1})
+	assert.Nil(err)
+
+	for _, a := range []*MatrixStruct{tall, wide} {
+		U, B, V := a.Bidiagonalize()
+		for i := 0; i < B.Rows; i++ {
+			for j := 0; j < B.Columns; j++ {
+				if j != i && j != i+1 {
+					assert.Equal(float64(0), B.Elements[i*B.Columns+j])
+				}
+			}
+		}
+
+		UB, _ := U.Multiply(B)
+		A, _ := UB.Multiply(V.Transpose())
+		assert.InDeltaSlice(a.Elements, A.Elements, 1e-12)
+
+		expected, _ := a.SingularValues()
+		actual, _ := B.SingularValues()
+		assert.InDeltaSlice(expected, actual, 1e-12)
+	}
+}
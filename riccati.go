@@ -0,0 +1,207 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// riccatiMaxIterations limits the doubling iteration for the discrete Riccati equation.
+const riccatiMaxIterations = 100
+
+// SolveDARE will return the stabilising solution X of the discrete algebraic Riccati equation along with the Frobenius norm of its residual.
+func SolveDARE(A, B, Q, R *MatrixStruct) (X *MatrixStruct, residual float64, err error) {
+	if err := checkRiccatiDimensions(A, B, Q, R); err != nil {
+		return nil, 0, err
+	}
+
+	G, err := riccatiG(B, R)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The structure preserving doubling algorithm solves A^T*X*A - X - A^T*X*B*(R + B^T*X*B)^-1*B^T*X*A + Q = 0
+	// with LU solves only, and converges quadratically when (A, B) is stabilisable and (A, Q) is detectable.
+	n := A.Rows
+	eye, _ := Eye(n, n)
+	Ak := A.Clone()
+	Gk := G
+	Hk := Q.Clone()
+
+	converged := false
+	for iter := 0; ; iter++ {
+		if iter == riccatiMaxIterations {
+			return nil, 0, errors.New("Riccati iteration did not converge")
+		}
+
+		GH, _ := Gk.Multiply(Hk)
+		W, _ := eye.Add(GH)
+		f := W.luDecompose()
+		if f.singular {
+			return nil, 0, errors.New("No stabilising solution exists")
+		}
+		WA, _ := f.solve(Ak)
+		WG, _ := f.solve(Gk)
+
+		AkT := Ak.Transpose()
+		next, _ := AkT.Multiply(Hk)
+		next, _ = next.Multiply(WA)
+		next.addScaled(1, Hk)
+
+		AWG, _ := Ak.Multiply(WG)
+		AWG, _ = AWG.Multiply(AkT)
+		Gk.addScaled(1, AWG)
+		Ak, _ = Ak.Multiply(WA)
+
+		diff, _ := next.Subtract(Hk)
+		Hk = next
+		if !Hk.isFinite() {
+			return nil, 0, errors.New("No stabilising solution exists")
+		}
+
+		// Convergence is quadratic, so one more step after reaching the square root of epsilon gives full accuracy.
+		if converged {
+			break
+		}
+		if diff.frobenius() <= math.Sqrt(epsilon)*Hk.frobenius() {
+			converged = true
+		}
+	}
+
+	X = Hk
+	X.symmetrize()
+	return X, dareResidual(A, B, Q, R, X), nil
+}
+
+// SolveCARE will return the stabilising solution X of the continuous algebraic Riccati equation along with the Frobenius norm of its residual.
+func SolveCARE(A, B, Q, R *MatrixStruct) (X *MatrixStruct, residual float64, err error) {
+	if err := checkRiccatiDimensions(A, B, Q, R); err != nil {
+		return nil, 0, err
+	}
+
+	G, err := riccatiG(B, R)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The stable invariant subspace of the Hamiltonian [A -B*R^-1*B^T; -Q -A^T] gives the solution of
+	// A^T*X + X*A - X*B*R^-1*B^T*X + Q = 0.
+	n := A.Rows
+	H, _ := Zeros(2*n, 2*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			H.Elements[i*2*n+j] = A.Elements[i*n+j]
+			H.Elements[i*2*n+n+j] = -G.Elements[i*n+j]
+			H.Elements[(n+i)*2*n+j] = -Q.Elements[i*n+j]
+			H.Elements[(n+i)*2*n+n+j] = -A.Elements[j*n+i]
+		}
+	}
+
+	Z, T, err := H.Schur()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Eigenvalues on the imaginary axis are often defective and so only resolved to about the square root of epsilon.
+	tol := math.Sqrt(epsilon) * H.frobenius()
+	stable := 0
+	for k := 0; k < 2*n; {
+		size := T.schurBlockSize(k)
+		value := T.schurBlockEigenvalues(k, size)[0]
+		if math.Abs(real(value)) <= tol {
+			return nil, 0, errors.New("No stabilising solution exists")
+		}
+		if real(value) < 0 {
+			stable += size
+		}
+		k += size
+	}
+	if stable != n {
+		return nil, 0, errors.New("No stabilising solution exists")
+	}
+
+	Z, _, err = SchurReorder(Z, T, func(value complex128) bool {
+		return real(value) < 0
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// X*Z11 = Z21 for the leading n columns of Z, solved as Z11^T*X^T = Z21^T.
+	Z11, _ := Zeros(n, n)
+	Z21, _ := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		copy(Z11.Elements[i*n:(i+1)*n], Z.Elements[i*2*n:i*2*n+n])
+		copy(Z21.Elements[i*n:(i+1)*n], Z.Elements[(n+i)*2*n:(n+i)*2*n+n])
+	}
+	f := Z11.Transpose().luDecompose()
+	if f.singular {
+		return nil, 0, errors.New("No stabilising solution exists")
+	}
+	XT, err := f.solve(Z21.Transpose())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	X = XT.Transpose()
+	X.symmetrize()
+	return X, careResidual(A, G, Q, X), nil
+}
+
+// checkRiccatiDimensions returns an error unless A and Q are n by n, B is n by m and R is m by m.
+func checkRiccatiDimensions(A, B, Q, R *MatrixStruct) error {
+	n, m := A.Rows, B.Columns
+	if !A.IsSquare() || B.Rows != n || Q.Rows != n || Q.Columns != n || R.Rows != m || R.Columns != m {
+		return errors.New("matrix dimensions do not agree")
+	}
+	return nil
+}
+
+// riccatiG returns B*R^-1*B^T.
+func riccatiG(B, R *MatrixStruct) (*MatrixStruct, error) {
+	f := R.luDecompose()
+	if f.singular {
+		return nil, errors.New("Matrix is singular")
+	}
+	RB, err := f.solve(B.Transpose())
+	if err != nil {
+		return nil, err
+	}
+	G, _ := B.Multiply(RB)
+	G.symmetrize()
+	return G, nil
+}
+
+// dareResidual returns the Frobenius norm of the left hand side of the discrete Riccati equation at X.
+func dareResidual(A, B, Q, R, X *MatrixStruct) float64 {
+	AT := A.Transpose()
+	XA, _ := X.Multiply(A)
+	ATXA, _ := AT.Multiply(XA)
+	BTXA, _ := B.Transpose().Multiply(XA)
+	BTXB, _ := X.Multiply(B)
+	BTXB, _ = B.Transpose().Multiply(BTXB)
+	S, _ := R.Add(BTXB)
+
+	f := S.luDecompose()
+	if f.singular {
+		return math.Inf(1)
+	}
+	K, _ := f.solve(BTXA)
+	correction, _ := BTXA.Transpose().Multiply(K)
+
+	res, _ := ATXA.Subtract(X)
+	res, _ = res.Subtract(correction)
+	res, _ = res.Add(Q)
+	return res.frobenius()
+}
+
+// careResidual returns the Frobenius norm of the left hand side of the continuous Riccati equation at X, with G = B*R^-1*B^T.
+func careResidual(A, G, Q, X *MatrixStruct) float64 {
+	XA, _ := X.Multiply(A)
+	XG, _ := X.Multiply(G)
+	XGX, _ := XG.Multiply(X)
+
+	res, _ := XA.Add(XA.Transpose())
+	res, _ = res.Subtract(XGX)
+	res, _ = res.Add(Q)
+	return res.frobenius()
+}
@@ -0,0 +1,103 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestSolveDARE(t *testing.T) {
+	assert := assert.New(t)
+
+	// With every matrix equal to one the equation reduces to x^2 = x + 1.
+	one, _ := Matrix(1, 1, []float64{1})
+	X, residual, err := SolveDARE(one, one, one, one)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{(1 + math.Sqrt(5)) / 2}, X.Elements, 1e-14)
+	assert.InDelta(0, residual, 1e-14)
+
+	// An unstable system with a single input.
+	A, err := Matrix(3, 3, []float64{1.1, 0.2, 0, 0, 0.9, 0.3, 0.1, 0, 1.05})
+	assert.Nil(err)
+	B, err := Matrix(3, 1, []float64{0, 0, 1})
+	assert.Nil(err)
+	Q, _ := Eye(3, 3)
+	R, _ := Matrix(1, 1, []float64{2})
+
+	X, residual, err = SolveDARE(A, B, Q, R)
+	assert.Nil(err)
+	assert.InDelta(0, residual, 1e-10)
+	assert.True(X.IsSymmetric())
+
+	// The closed loop A - B*K with K = (R + B^T*X*B)^-1*B^T*X*A must be stable.
+	XA, _ := X.Multiply(A)
+	BTXA, _ := B.Transpose().Multiply(XA)
+	XB, _ := X.Multiply(B)
+	S, _ := B.Transpose().Multiply(XB)
+	S, _ = S.Add(R)
+	K, err := S.Solve(BTXA)
+	assert.Nil(err)
+	BK, _ := B.Multiply(K)
+	closed, _ := A.Subtract(BK)
+	values, err := closed.Eigen()
+	assert.Nil(err)
+	for _, value := range values {
+		assert.True(cmplx.Abs(value) < 1)
+	}
+
+	_, _, err = SolveDARE(A, B, Q, Q)
+	assert.NotNil(err)
+}
+
+func BenchmarkSolveDARE(b *testing.B) {
+	A, _ := Matrix(3, 3, []float64{1.1, 0.2, 0, 0, 0.9, 0.3, 0.1, 0, 1.05})
+	B, _ := Matrix(3, 1, []float64{0, 0, 1})
+	Q, _ := Eye(3, 3)
+	R, _ := Matrix(1, 1, []float64{2})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = SolveDARE(A, B, Q, R)
+	}
+}
+
+func TestSolveCARE(t *testing.T) {
+	assert := assert.New(t)
+
+	// With every matrix equal to one the equation reduces to x^2 - 2x - 1 = 0.
+	one, _ := Matrix(1, 1, []float64{1})
+	X, residual, err := SolveCARE(one, one, one, one)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1 + math.Sqrt(2)}, X.Elements, 1e-13)
+	assert.InDelta(0, residual, 1e-13)
+
+	// A double integrator, the textbook linear quadratic regulator example.
+	A, err := Matrix(2, 2, []float64{0, 1, 0, 0})
+	assert.Nil(err)
+	B, err := Matrix(2, 1, []float64{0, 1})
+	assert.Nil(err)
+	Q, _ := Eye(2, 2)
+	X, residual, err = SolveCARE(A, B, Q, one)
+	assert.Nil(err)
+	assert.InDelta(0, residual, 1e-12)
+	s3 := math.Sqrt(3)
+	assert.InDeltaSlice([]float64{s3, 1, 1, s3}, X.Elements, 1e-12)
+
+	// With no input and an undamped oscillator there is no stabilising solution.
+	C, _ := Matrix(2, 2, []float64{0, 1, -1, 0})
+	zero, _ := Zeros(2, 1)
+	_, _, err = SolveCARE(C, zero, Q, one)
+	assert.NotNil(err)
+
+	_, _, err = SolveCARE(A, B, one, one)
+	assert.NotNil(err)
+}
+
+func BenchmarkSolveCARE(b *testing.B) {
+	A, _ := Matrix(2, 2, []float64{0, 1, 0, 0})
+	B, _ := Matrix(2, 1, []float64{0, 1})
+	Q, _ := Eye(2, 2)
+	R, _ := Matrix(1, 1, []float64{1})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = SolveCARE(A, B, Q, R)
+	}
+}
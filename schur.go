@@ -0,0 +1,185 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// Schur will return the real Schur decomposition m = Z*T*Z^T, where Z is orthogonal and T is upper triangular apart from 2x2 blocks for complex eigenvalues.
+func (m MatrixStruct) Schur() (Z, T *MatrixStruct, err error) {
+	if !m.IsSquare() {
+		return nil, nil, errors.New("Not a square matrix")
+	}
+
+	T, Z = m.hessenberg()
+	if _, _, err := schurIterate(T, Z); err != nil {
+		return nil, nil, err
+	}
+
+	n := m.Rows
+	for i := 2; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			T.Elements[i*n+j] = 0
+		}
+	}
+	return Z, T, nil
+}
+
+// SchurReorder will reorder a real Schur decomposition so that the eigenvalues for which selected returns true appear first on the diagonal of T.
+func SchurReorder(Z, T *MatrixStruct, selected func(complex128) bool) (*MatrixStruct, *MatrixStruct, error) {
+	if !T.IsSquare() || !Z.IsSquare() || Z.Rows != T.Rows {
+		return nil, nil, errors.New("matrix dimensions do not agree")
+	}
+
+	n := T.Rows
+	for i := 1; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			if T.Elements[i*n+j] != 0 {
+				return nil, nil, errors.New("Not a quasi upper triangular matrix")
+			}
+		}
+		if i+1 < n && T.Elements[i*n+i-1] != 0 && T.Elements[(i+1)*n+i] != 0 {
+			return nil, nil, errors.New("Not a quasi upper triangular matrix")
+		}
+	}
+
+	Z1 := Z.Clone()
+	T1 := T.Clone()
+
+	next := 0
+	for k := 0; k < n; {
+		size := T1.schurBlockSize(k)
+		values := T1.schurBlockEigenvalues(k, size)
+		// A complex conjugate pair is moved if either of its eigenvalues is selected.
+		if !selected(values[0]) && !selected(values[len(values)-1]) {
+			k += size
+			continue
+		}
+
+		// Bubble the block up to position next one neighbour at a time, failing if the eigenvalues are too close to swap stably.
+		for here := k; here > next; {
+			previous := here - 1
+			if previous > 0 && T1.Elements[previous*n+previous-1] != 0 {
+				previous--
+			}
+			if err := swapSchurBlocks(Z1, T1, previous, here-previous, size); err != nil {
+				return nil, nil, err
+			}
+			here = previous
+		}
+		next += size
+		k += size
+	}
+
+	return Z1, T1, nil
+}
+
+// schurBlockSize returns 2 if a 2x2 block starts at row k of a quasi upper triangular matrix and 1 otherwise.
+func (m MatrixStruct) schurBlockSize(k int) int {
+	if k+1 < m.Rows && m.Elements[(k+1)*m.Columns+k] != 0 {
+		return 2
+	}
+	return 1
+}
+
+// schurBlockEigenvalues returns the eigenvalues of the diagonal block of the given size starting at row k.
+func (m MatrixStruct) schurBlockEigenvalues(k, size int) []complex128 {
+	n := m.Columns
+	if size == 1 {
+		return []complex128{complex(m.Elements[k*n+k], 0)}
+	}
+
+	a, b := m.Elements[k*n+k], m.Elements[k*n+k+1]
+	c, d := m.Elements[(k+1)*n+k], m.Elements[(k+1)*n+k+1]
+	p := (a - d) / 2
+	disc := p*p + b*c
+	mean := (a + d) / 2
+	if disc >= 0 {
+		root := math.Sqrt(disc)
+		return []complex128{complex(mean+root, 0), complex(mean-root, 0)}
+	}
+	root := math.Sqrt(-disc)
+	return []complex128{complex(mean, root), complex(mean, -root)}
+}
+
+// swapSchurBlocks exchanges the adjacent diagonal blocks of T starting at row j with sizes n1 and n2, updating Z so that Z*T*Z^T is unchanged.
+func swapSchurBlocks(Z, T *MatrixStruct, j, n1, n2 int) error {
+	n := T.Rows
+	p := n1 + n2
+
+	// Solve T11*X - X*T22 = T12 through its Kronecker form, X is n1 by n2 and stored by rows.
+	K, _ := Zeros(n1*n2, n1*n2)
+	rhs, _ := Zeros(n1*n2, 1)
+	for r := 0; r < n1; r++ {
+		for c := 0; c < n2; c++ {
+			row := r*n2 + c
+			rhs.Elements[row] = T.Elements[(j+r)*n+j+n1+c]
+			for k := 0; k < n1; k++ {
+				K.Elements[row*n1*n2+k*n2+c] += T.Elements[(j+r)*n+j+k]
+			}
+			for k := 0; k < n2; k++ {
+				K.Elements[row*n1*n2+r*n2+k] -= T.Elements[(j+n1+k)*n+j+n1+c]
+			}
+		}
+	}
+
+	f := K.luDecompose()
+	if f.singular {
+		return errors.New("Schur blocks have eigenvalues that are too close to swap")
+	}
+	X, err := f.solve(rhs)
+	if err != nil {
+		return err
+	}
+
+	// The columns of [-X; I] span the invariant subspace belonging to T22.
+	S, _ := Zeros(p, n2)
+	for r := 0; r < n1; r++ {
+		for c := 0; c < n2; c++ {
+			S.Elements[r*n2+c] = -X.Elements[r*n2+c]
+		}
+	}
+	for c := 0; c < n2; c++ {
+		S.Elements[(n1+c)*n2+c] = 1
+	}
+	Q := S.QRCompact().Q(false)
+
+	norm := T.maxAbs()
+	block, _ := Zeros(p, n)
+	for r := 0; r < p; r++ {
+		copy(block.Elements[r*n:(r+1)*n], T.Elements[(j+r)*n:(j+r+1)*n])
+	}
+	block, _ = Q.Transpose().Multiply(block)
+	for r := 0; r < p; r++ {
+		copy(T.Elements[(j+r)*n:(j+r+1)*n], block.Elements[r*n:(r+1)*n])
+	}
+	T.multiplyColumns(Q, j)
+	Z.multiplyColumns(Q, j)
+
+	// The new lower left block should vanish, reject the swap if it is not negligible.
+	for r := n2; r < p; r++ {
+		for c := 0; c < n2; c++ {
+			if math.Abs(T.Elements[(j+r)*n+j+c]) > 10*epsilon*float64(n)*norm {
+				return errors.New("Schur blocks have eigenvalues that are too close to swap")
+			}
+			T.Elements[(j+r)*n+j+c] = 0
+		}
+	}
+	return nil
+}
+
+// multiplyColumns replaces the columns of m starting at column with those columns multiplied on the right by Q.
+func (m MatrixStruct) multiplyColumns(Q *MatrixStruct, column int) {
+	p := Q.Rows
+	row := make([]float64, p)
+	for i := 0; i < m.Rows; i++ {
+		for c := 0; c < p; c++ {
+			sum := float64(0)
+			for k := 0; k < p; k++ {
+				sum += m.Elements[i*m.Columns+column+k] * Q.Elements[k*p+c]
+			}
+			row[c] = sum
+		}
+		copy(m.Elements[i*m.Columns+column:i*m.Columns+column+p], row)
+	}
+}
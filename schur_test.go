@@ -0,0 +1,103 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func assertSchur(assert *assert.Assertions, a, Z, T *MatrixStruct) {
+	n := a.Rows
+	for i := 0; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			assert.Equal(float64(0), T.Elements[i*n+j])
+		}
+	}
+	for i := 1; i < n-1; i++ {
+		assert.False(T.Elements[i*n+i-1] != 0 && T.Elements[(i+1)*n+i] != 0)
+	}
+
+	ZT, _ := Z.Multiply(T)
+	A, _ := ZT.Multiply(Z.Transpose())
+	assert.InDeltaSlice(a.Elements, A.Elements, 1e-10)
+
+	ZTZ, _ := Z.Transpose().Multiply(Z)
+	eye, _ := Eye(n, n)
+	assert.InDeltaSlice(eye.Elements, ZTZ.Elements, 1e-12)
+}
+
+func TestSchur(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(5, 5, []float64{
+		1, 2, 0, -1, 3,
+		-2, 1, 4, 0, 1,
+		0, -3, 2, 1, 0,
+		5, 0, -1, 3, 2,
+		1, 1, 1, -4, 0,
+	})
+	assert.Nil(err)
+
+	Z, T, err := a.Schur()
+	assert.Nil(err)
+	assertSchur(assert, a, Z, T)
+
+	b, err := Matrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	assert.Nil(err)
+	_, _, err = b.Schur()
+	assert.NotNil(err)
+}
+
+func BenchmarkSchur(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = a.Schur()
+	}
+}
+
+func TestSchurReorder(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(5, 5, []float64{
+		1, 2, 0, -1, 3,
+		-2, 1, 4, 0, 1,
+		0, -3, 2, 1, 0,
+		5, 0, -1, 3, 2,
+		1, 1, 1, -4, 0,
+	})
+	assert.Nil(err)
+
+	Z, T, err := a.Schur()
+	assert.Nil(err)
+
+	for _, selected := range []func(complex128) bool{
+		func(value complex128) bool { return real(value) < 1 },
+		func(value complex128) bool { return imag(value) == 0 },
+		func(value complex128) bool { return imag(value) != 0 },
+	} {
+		Z1, T1, err := SchurReorder(Z, T, selected)
+		assert.Nil(err)
+		assertSchur(assert, a, Z1, T1)
+
+		passed := false
+		for k := 0; k < 5; {
+			size := T1.schurBlockSize(k)
+			chosen := selected(T1.schurBlockEigenvalues(k, size)[0])
+			if !chosen {
+				passed = true
+			}
+			assert.False(passed && chosen)
+			k += size
+		}
+	}
+
+	c, err := Matrix(3, 3, []float64{1, 1, 1, 0, 2, 1, 0, 0, 3})
+	assert.Nil(err)
+	eye, _ := Eye(3, 3)
+	Z1, T1, err := SchurReorder(eye, c, func(value complex128) bool { return real(value) > 2.5 })
+	assert.Nil(err)
+	assert.InDelta(3, T1.Elements[0], 1e-12)
+	assertSchur(assert, c, Z1, T1)
+
+	_, _, err = SchurReorder(eye, a, func(complex128) bool { return true })
+	assert.NotNil(err)
+}
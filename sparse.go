@@ -0,0 +1,681 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Interface is implemented by every matrix type in this package, so code that only reads a matrix or multiplies it with vectors can accept any of them.
+type Interface interface {
+	// Dims returns the number of rows and columns.
+	Dims() (rows, columns int)
+	// GetValue returns the element in row y and column x.
+	GetValue(y, x int) (float64, error)
+	// MulVec writes the product of the matrix and x into dst.
+	MulVec(dst, x []float64)
+	// T returns the transpose in the same storage format.
+	T() Interface
+	// Dense returns a dense copy of the matrix.
+	Dense() *MatrixStruct
+}
+
+// CSR is a sparse matrix in compressed sparse row format, the format the sparse factorizations work in.
+type CSR struct {
+	Rows, Columns int
+	// Row i is held in Values[RowPointers[i]:RowPointers[i+1]], sorted by column with no duplicates.
+	RowPointers   []int
+	ColumnIndices []int
+	Values        []float64
+}
+
+// CSC is a sparse matrix in compressed sparse column format, the transpose of the CSR layout.
+type CSC struct {
+	Rows, Columns int
+	// Column j is held in Values[ColumnPointers[j]:ColumnPointers[j+1]], sorted by row with no duplicates.
+	ColumnPointers []int
+	RowIndices     []int
+	Values         []float64
+}
+
+// COO is a sparse matrix in coordinate format, which is the easiest to assemble a matrix in before converting it.
+type COO struct {
+	Rows, Columns int
+	// Elements may be stored in any order, and duplicates are summed.
+	RowIndices    []int
+	ColumnIndices []int
+	Values        []float64
+}
+
+// NewCSR will return a CSR matrix that uses the given arrays directly, after checking that they describe a valid matrix.
+func NewCSR(rows, columns int, rowPointers, columnIndices []int, values []float64) (*CSR, error) {
+	if rows < 0 || columns < 0 || len(rowPointers) != rows+1 || rowPointers[0] != 0 {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+	nnz := rowPointers[rows]
+	if len(columnIndices) != nnz || len(values) != nnz {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	for i := 0; i < rows; i++ {
+		if rowPointers[i+1] < rowPointers[i] {
+			return nil, errors.New("Row pointers must not decrease")
+		}
+		for p := rowPointers[i]; p < rowPointers[i+1]; p++ {
+			j := columnIndices[p]
+			if j < 0 || j >= columns {
+				return nil, errors.New("Matrix index is out of range")
+			}
+			if p > rowPointers[i] && j <= columnIndices[p-1] {
+				return nil, errors.New("Column indices must be sorted and unique within a row")
+			}
+		}
+	}
+
+	return &CSR{Rows: rows, Columns: columns, RowPointers: rowPointers, ColumnIndices: columnIndices, Values: values}, nil
+}
+
+// NewCSC will return a CSC matrix that uses the given arrays directly, after checking that they describe a valid matrix.
+func NewCSC(rows, columns int, columnPointers, rowIndices []int, values []float64) (*CSC, error) {
+	t, err := NewCSR(columns, rows, columnPointers, rowIndices, values)
+	if err != nil {
+		return nil, err
+	}
+	return t.asCSCOfTranspose(), nil
+}
+
+// NewCOO will return an empty COO matrix of the given shape, ready for elements to be appended.
+func NewCOO(rows, columns int) (*COO, error) {
+	if rows < 0 || columns < 0 {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+	return &COO{Rows: rows, Columns: columns}, nil
+}
+
+// Append will add value to the element in row y and column x, summing it with anything already appended there.
+func (c *COO) Append(y, x int, value float64) error {
+	if y < 0 || y >= c.Rows || x < 0 || x >= c.Columns {
+		return fmt.Errorf("The indexes must be in the bounds of the matrix.\nMatrix is %dx%d, indexes are %d,%d", c.Rows, c.Columns, y, x)
+	}
+
+	c.RowIndices = append(c.RowIndices, y)
+	c.ColumnIndices = append(c.ColumnIndices, x)
+	c.Values = append(c.Values, value)
+	return nil
+}
+
+// T will return the transpose of the matrix, which satisfies Interface.
+func (m MatrixStruct) T() Interface {
+	return m.Transpose()
+}
+
+// Dense will return a copy of the matrix, which satisfies Interface.
+func (m MatrixStruct) Dense() *MatrixStruct {
+	return m.Clone()
+}
+
+// CSR will return the nonzero elements of the matrix in compressed sparse row format.
+func (m MatrixStruct) CSR() *CSR {
+	a := &CSR{Rows: m.Rows, Columns: m.Columns, RowPointers: make([]int, m.Rows+1)}
+	for i := 0; i < m.Rows; i++ {
+		for j, value := range m.Elements[i*m.Columns : (i+1)*m.Columns] {
+			if value != 0 {
+				a.ColumnIndices = append(a.ColumnIndices, j)
+				a.Values = append(a.Values, value)
+			}
+		}
+		a.RowPointers[i+1] = len(a.Values)
+	}
+	return a
+}
+
+// CSC will return the nonzero elements of the matrix in compressed sparse column format.
+func (m MatrixStruct) CSC() *CSC {
+	return m.Transpose().CSR().asCSCOfTranspose()
+}
+
+// COO will return the nonzero elements of the matrix in coordinate format, ordered by row.
+func (m MatrixStruct) COO() *COO {
+	return m.CSR().COO()
+}
+
+// Dims will return the number of rows and columns.
+func (a *CSR) Dims() (rows, columns int) {
+	return a.Rows, a.Columns
+}
+
+// NonZeros will return the number of stored elements.
+func (a *CSR) NonZeros() int {
+	return len(a.Values)
+}
+
+// GetValue will return the element in row y and column x, found by a binary search of the row.
+func (a *CSR) GetValue(y, x int) (float64, error) {
+	if y < 0 || y >= a.Rows || x < 0 || x >= a.Columns {
+		return 0, fmt.Errorf("The indexes must be in the bounds of the matrix.\nMatrix is %dx%d, indexes are %d,%d", a.Rows, a.Columns, y, x)
+	}
+
+	start, end := a.RowPointers[y], a.RowPointers[y+1]
+	p := start + sort.SearchInts(a.ColumnIndices[start:end], x)
+	if p < end && a.ColumnIndices[p] == x {
+		return a.Values[p], nil
+	}
+	return 0, nil
+}
+
+// MulVec will write the product of the matrix and x into dst.
+func (a *CSR) MulVec(dst, x []float64) {
+	for i := 0; i < a.Rows; i++ {
+		sum := float64(0)
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			sum += a.Values[p] * x[a.ColumnIndices[p]]
+		}
+		dst[i] = sum
+	}
+}
+
+// T will return the transpose of the matrix, which satisfies Interface.
+func (a *CSR) T() Interface {
+	return a.Transpose()
+}
+
+// Transpose will return the transpose of the matrix in CSR format, built with a counting sort in O(rows + columns + nonzeros) time.
+func (a *CSR) Transpose() *CSR {
+	t := &CSR{
+		Rows:          a.Columns,
+		Columns:       a.Rows,
+		RowPointers:   make([]int, a.Columns+1),
+		ColumnIndices: make([]int, len(a.Values)),
+		Values:        make([]float64, len(a.Values)),
+	}
+
+	for _, j := range a.ColumnIndices {
+		t.RowPointers[j+1]++
+	}
+	for j := 0; j < a.Columns; j++ {
+		t.RowPointers[j+1] += t.RowPointers[j]
+	}
+
+	next := append([]int(nil), t.RowPointers[:a.Columns]...)
+	for i := 0; i < a.Rows; i++ {
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			q := next[a.ColumnIndices[p]]
+			t.ColumnIndices[q] = i
+			t.Values[q] = a.Values[p]
+			next[a.ColumnIndices[p]]++
+		}
+	}
+	return t
+}
+
+// Dense will return the matrix as a dense MatrixStruct.
+func (a *CSR) Dense() *MatrixStruct {
+	m, _ := Zeros(a.Rows, a.Columns)
+	for i := 0; i < a.Rows; i++ {
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			m.Elements[i*a.Columns+a.ColumnIndices[p]] = a.Values[p]
+		}
+	}
+	return m
+}
+
+// CSC will return the matrix in compressed sparse column format.
+func (a *CSR) CSC() *CSC {
+	return a.Transpose().asCSCOfTranspose()
+}
+
+// COO will return the matrix in coordinate format, ordered by row.
+func (a *CSR) COO() *COO {
+	c := &COO{
+		Rows:          a.Rows,
+		Columns:       a.Columns,
+		RowIndices:    make([]int, 0, len(a.Values)),
+		ColumnIndices: append([]int(nil), a.ColumnIndices...),
+		Values:        append([]float64(nil), a.Values...),
+	}
+	for i := 0; i < a.Rows; i++ {
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			c.RowIndices = append(c.RowIndices, i)
+		}
+	}
+	return c
+}
+
+// Clone will return a copy of the matrix that shares no memory with the original.
+func (a *CSR) Clone() *CSR {
+	return &CSR{
+		Rows:          a.Rows,
+		Columns:       a.Columns,
+		RowPointers:   append([]int(nil), a.RowPointers...),
+		ColumnIndices: append([]int(nil), a.ColumnIndices...),
+		Values:        append([]float64(nil), a.Values...),
+	}
+}
+
+// ScalarMultiply will return a new matrix with every element multiplied by s.
+func (a *CSR) ScalarMultiply(s float64) *CSR {
+	b := a.Clone()
+	for i := range b.Values {
+		b.Values[i] *= s
+	}
+	return b
+}
+
+// Add will return the sum of two CSR matrices of the same shape.
+func (a *CSR) Add(b *CSR) (*CSR, error) {
+	if a.Rows != b.Rows || a.Columns != b.Columns {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	c := &CSR{Rows: a.Rows, Columns: a.Columns, RowPointers: make([]int, a.Rows+1)}
+	for i := 0; i < a.Rows; i++ {
+		p, pEnd := a.RowPointers[i], a.RowPointers[i+1]
+		q, qEnd := b.RowPointers[i], b.RowPointers[i+1]
+		// Merging the sorted rows gives the union of the two patterns.
+		for p < pEnd || q < qEnd {
+			switch {
+			case q == qEnd || (p < pEnd && a.ColumnIndices[p] < b.ColumnIndices[q]):
+				c.ColumnIndices = append(c.ColumnIndices, a.ColumnIndices[p])
+				c.Values = append(c.Values, a.Values[p])
+				p++
+			case p == pEnd || b.ColumnIndices[q] < a.ColumnIndices[p]:
+				c.ColumnIndices = append(c.ColumnIndices, b.ColumnIndices[q])
+				c.Values = append(c.Values, b.Values[q])
+				q++
+			default:
+				c.ColumnIndices = append(c.ColumnIndices, a.ColumnIndices[p])
+				c.Values = append(c.Values, a.Values[p]+b.Values[q])
+				p++
+				q++
+			}
+		}
+		c.RowPointers[i+1] = len(c.Values)
+	}
+	return c, nil
+}
+
+// Multiply will return the dense product of the sparse matrix and a dense matrix.
+func (a *CSR) Multiply(b *MatrixStruct) (*MatrixStruct, error) {
+	if a.Columns != b.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	c, _ := Zeros(a.Rows, b.Columns)
+	for i := 0; i < a.Rows; i++ {
+		row := c.Elements[i*b.Columns : (i+1)*b.Columns]
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			axpy(a.Values[p], b.Elements[a.ColumnIndices[p]*b.Columns:(a.ColumnIndices[p]+1)*b.Columns], row)
+		}
+	}
+	return c, nil
+}
+
+// MultiplySparse will return the sparse product of two CSR matrices using Gustavson's row by row algorithm.
+func (a *CSR) MultiplySparse(b *CSR) (*CSR, error) {
+	if a.Columns != b.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	c := &CSR{Rows: a.Rows, Columns: b.Columns, RowPointers: make([]int, a.Rows+1)}
+	accumulator := make([]float64, b.Columns)
+	marker := make([]int, b.Columns)
+	for j := range marker {
+		marker[j] = -1
+	}
+
+	// The work is proportional to the number of multiplications rather than the size of the matrices.
+	for i := 0; i < a.Rows; i++ {
+		start := len(c.ColumnIndices)
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			k, value := a.ColumnIndices[p], a.Values[p]
+			for q := b.RowPointers[k]; q < b.RowPointers[k+1]; q++ {
+				j := b.ColumnIndices[q]
+				if marker[j] != i {
+					marker[j] = i
+					accumulator[j] = 0
+					c.ColumnIndices = append(c.ColumnIndices, j)
+				}
+				accumulator[j] += value * b.Values[q]
+			}
+		}
+
+		columns := c.ColumnIndices[start:]
+		sort.Ints(columns)
+		for _, j := range columns {
+			c.Values = append(c.Values, accumulator[j])
+		}
+		c.RowPointers[i+1] = len(c.ColumnIndices)
+	}
+	return c, nil
+}
+
+// asCSCOfTranspose reinterprets the CSR arrays of a matrix as the CSC arrays of its transpose, sharing memory.
+func (a *CSR) asCSCOfTranspose() *CSC {
+	return &CSC{Rows: a.Columns, Columns: a.Rows, ColumnPointers: a.RowPointers, RowIndices: a.ColumnIndices, Values: a.Values}
+}
+
+// asCSROfTranspose reinterprets the CSC arrays of a matrix as the CSR arrays of its transpose, sharing memory.
+func (c *CSC) asCSROfTranspose() *CSR {
+	return &CSR{Rows: c.Columns, Columns: c.Rows, RowPointers: c.ColumnPointers, ColumnIndices: c.RowIndices, Values: c.Values}
+}
+
+// Dims will return the number of rows and columns.
+func (c *CSC) Dims() (rows, columns int) {
+	return c.Rows, c.Columns
+}
+
+// NonZeros will return the number of stored elements.
+func (c *CSC) NonZeros() int {
+	return len(c.Values)
+}
+
+// GetValue will return the element in row y and column x, found by a binary search of the column.
+func (c *CSC) GetValue(y, x int) (float64, error) {
+	if y < 0 || y >= c.Rows || x < 0 || x >= c.Columns {
+		return 0, fmt.Errorf("The indexes must be in the bounds of the matrix.\nMatrix is %dx%d, indexes are %d,%d", c.Rows, c.Columns, y, x)
+	}
+	return c.asCSROfTranspose().GetValue(x, y)
+}
+
+// MulVec will write the product of the matrix and x into dst.
+func (c *CSC) MulVec(dst, x []float64) {
+	for i := range dst[:c.Rows] {
+		dst[i] = 0
+	}
+	for j := 0; j < c.Columns; j++ {
+		for p := c.ColumnPointers[j]; p < c.ColumnPointers[j+1]; p++ {
+			dst[c.RowIndices[p]] += c.Values[p] * x[j]
+		}
+	}
+}
+
+// T will return the transpose of the matrix, which satisfies Interface.
+func (c *CSC) T() Interface {
+	return c.Transpose()
+}
+
+// Transpose will return the transpose of the matrix in CSC format.
+func (c *CSC) Transpose() *CSC {
+	return c.asCSROfTranspose().Transpose().asCSCOfTranspose()
+}
+
+// Dense will return the matrix as a dense MatrixStruct.
+func (c *CSC) Dense() *MatrixStruct {
+	return c.asCSROfTranspose().Dense().Transpose()
+}
+
+// CSR will return the matrix in compressed sparse row format.
+func (c *CSC) CSR() *CSR {
+	return c.asCSROfTranspose().Transpose()
+}
+
+// COO will return the matrix in coordinate format, ordered by column.
+func (c *CSC) COO() *COO {
+	return c.asCSROfTranspose().COO().Transpose()
+}
+
+// Add will return the sum of two CSC matrices of the same shape.
+func (c *CSC) Add(b *CSC) (*CSC, error) {
+	sum, err := c.asCSROfTranspose().Add(b.asCSROfTranspose())
+	if err != nil {
+		return nil, err
+	}
+	return sum.asCSCOfTranspose(), nil
+}
+
+// Multiply will return the dense product of the sparse matrix and a dense matrix.
+func (c *CSC) Multiply(b *MatrixStruct) (*MatrixStruct, error) {
+	if c.Columns != b.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	product, _ := Zeros(c.Rows, b.Columns)
+	for j := 0; j < c.Columns; j++ {
+		row := b.Elements[j*b.Columns : (j+1)*b.Columns]
+		for p := c.ColumnPointers[j]; p < c.ColumnPointers[j+1]; p++ {
+			i := c.RowIndices[p]
+			axpy(c.Values[p], row, product.Elements[i*b.Columns:(i+1)*b.Columns])
+		}
+	}
+	return product, nil
+}
+
+// MultiplySparse will return the sparse product of two CSC matrices.
+func (c *CSC) MultiplySparse(b *CSC) (*CSC, error) {
+	if c.Columns != b.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	// (B^T*A^T)^T lets the CSR algorithm work on the same arrays.
+	product, err := b.asCSROfTranspose().MultiplySparse(c.asCSROfTranspose())
+	if err != nil {
+		return nil, err
+	}
+	return product.asCSCOfTranspose(), nil
+}
+
+// Dims will return the number of rows and columns.
+func (c *COO) Dims() (rows, columns int) {
+	return c.Rows, c.Columns
+}
+
+// NonZeros will return the number of stored elements, counting duplicates separately.
+func (c *COO) NonZeros() int {
+	return len(c.Values)
+}
+
+// GetValue will return the element in row y and column x, summing any duplicates.
+func (c *COO) GetValue(y, x int) (float64, error) {
+	if y < 0 || y >= c.Rows || x < 0 || x >= c.Columns {
+		return 0, fmt.Errorf("The indexes must be in the bounds of the matrix.\nMatrix is %dx%d, indexes are %d,%d", c.Rows, c.Columns, y, x)
+	}
+
+	// This scans every stored element, convert to CSR or CSC for repeated lookups.
+	sum := float64(0)
+	for p, value := range c.Values {
+		if c.RowIndices[p] == y && c.ColumnIndices[p] == x {
+			sum += value
+		}
+	}
+	return sum, nil
+}
+
+// MulVec will write the product of the matrix and x into dst.
+func (c *COO) MulVec(dst, x []float64) {
+	for i := range dst[:c.Rows] {
+		dst[i] = 0
+	}
+	for p, value := range c.Values {
+		dst[c.RowIndices[p]] += value * x[c.ColumnIndices[p]]
+	}
+}
+
+// T will return the transpose of the matrix, which satisfies Interface.
+func (c *COO) T() Interface {
+	return c.Transpose()
+}
+
+// Transpose will return the transpose of the matrix in coordinate format, which only swaps the index arrays.
+func (c *COO) Transpose() *COO {
+	return &COO{
+		Rows:          c.Columns,
+		Columns:       c.Rows,
+		RowIndices:    append([]int(nil), c.ColumnIndices...),
+		ColumnIndices: append([]int(nil), c.RowIndices...),
+		Values:        append([]float64(nil), c.Values...),
+	}
+}
+
+// Dense will return the matrix as a dense MatrixStruct, summing any duplicates.
+func (c *COO) Dense() *MatrixStruct {
+	m, _ := Zeros(c.Rows, c.Columns)
+	for p, value := range c.Values {
+		m.Elements[c.RowIndices[p]*c.Columns+c.ColumnIndices[p]] += value
+	}
+	return m
+}
+
+// CSR will return the matrix in compressed sparse row format, summing any duplicates.
+func (c *COO) CSR() *CSR {
+	// Two stable counting sorts, first by column and then by row, leave every row sorted by column.
+	byColumn := bucket(c.ColumnIndices, c.Columns, identity(len(c.Values)))
+	order := bucket(c.RowIndices, c.Rows, byColumn)
+
+	a := &CSR{Rows: c.Rows, Columns: c.Columns, RowPointers: make([]int, c.Rows+1)}
+	for k, p := range order {
+		i, j := c.RowIndices[p], c.ColumnIndices[p]
+		if k > 0 && c.RowIndices[order[k-1]] == i && c.ColumnIndices[order[k-1]] == j {
+			a.Values[len(a.Values)-1] += c.Values[p]
+			continue
+		}
+		a.ColumnIndices = append(a.ColumnIndices, j)
+		a.Values = append(a.Values, c.Values[p])
+		a.RowPointers[i+1] = len(a.Values)
+	}
+
+	// Empty rows still need their pointer carried forward.
+	for i := 0; i < c.Rows; i++ {
+		if a.RowPointers[i+1] < a.RowPointers[i] {
+			a.RowPointers[i+1] = a.RowPointers[i]
+		}
+	}
+	return a
+}
+
+// CSC will return the matrix in compressed sparse column format, summing any duplicates.
+func (c *COO) CSC() *CSC {
+	return c.Transpose().CSR().asCSCOfTranspose()
+}
+
+// Add will return the sum of two COO matrices of the same shape.
+func (c *COO) Add(b *COO) (*COO, error) {
+	if c.Rows != b.Rows || c.Columns != b.Columns {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	// Duplicates are summed when the result is converted.
+	return &COO{
+		Rows:          c.Rows,
+		Columns:       c.Columns,
+		RowIndices:    append(append([]int(nil), c.RowIndices...), b.RowIndices...),
+		ColumnIndices: append(append([]int(nil), c.ColumnIndices...), b.ColumnIndices...),
+		Values:        append(append([]float64(nil), c.Values...), b.Values...),
+	}, nil
+}
+
+// Multiply will return the dense product of the sparse matrix and a dense matrix.
+func (c *COO) Multiply(b *MatrixStruct) (*MatrixStruct, error) {
+	if c.Columns != b.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	product, _ := Zeros(c.Rows, b.Columns)
+	for p, value := range c.Values {
+		i, j := c.RowIndices[p], c.ColumnIndices[p]
+		axpy(value, b.Elements[j*b.Columns:(j+1)*b.Columns], product.Elements[i*b.Columns:(i+1)*b.Columns])
+	}
+	return product, nil
+}
+
+// MultiplySparse will return the sparse product of two COO matrices, converting both to CSR for the multiplication.
+func (c *COO) MultiplySparse(b *COO) (*COO, error) {
+	if c.Columns != b.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	product, err := c.CSR().MultiplySparse(b.CSR())
+	if err != nil {
+		return nil, err
+	}
+	return product.COO(), nil
+}
+
+// band returns a copy of the elements whose column lies between the row offset by lower and upper inclusive.
+func (a *CSR) band(lower, upper int) *CSR {
+	b := &CSR{Rows: a.Rows, Columns: a.Columns, RowPointers: make([]int, a.Rows+1)}
+	// The diagonal is always included, as an explicit zero if necessary, so that factorizations have somewhere to put their pivots.
+	for i := 0; i < a.Rows; i++ {
+		diagonal := i >= a.Columns
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			j := a.ColumnIndices[p]
+			if j-i < lower || j-i > upper {
+				continue
+			}
+			if !diagonal && j > i {
+				b.ColumnIndices = append(b.ColumnIndices, i)
+				b.Values = append(b.Values, 0)
+			}
+			diagonal = diagonal || j >= i
+			b.ColumnIndices = append(b.ColumnIndices, j)
+			b.Values = append(b.Values, a.Values[p])
+		}
+		if !diagonal {
+			b.ColumnIndices = append(b.ColumnIndices, i)
+			b.Values = append(b.Values, 0)
+		}
+		b.RowPointers[i+1] = len(b.Values)
+	}
+	return b
+}
+
+// diagonal returns the position of the diagonal element of each row, or -1 for a row without one.
+func (a *CSR) diagonal() []int {
+	diag := make([]int, a.Rows)
+	for i := range diag {
+		diag[i] = -1
+		for p := a.RowPointers[i]; p < a.RowPointers[i+1]; p++ {
+			if a.ColumnIndices[p] == i {
+				diag[i] = p
+			}
+		}
+	}
+	return diag
+}
+
+// IsSymmetric will report whether the matrix is square and equal to its transpose.
+func (a *CSR) IsSymmetric() bool {
+	if a.Rows != a.Columns {
+		return false
+	}
+
+	// The tolerance is proportional to the size of the largest element.
+	max := float64(0)
+	for _, value := range a.Values {
+		max = math.Max(max, math.Abs(value))
+	}
+	diff, _ := a.Add(a.Transpose().ScalarMultiply(-1))
+	for _, value := range diff.Values {
+		if math.Abs(value) > float64(a.Rows)*epsilon*max {
+			return false
+		}
+	}
+	return true
+}
+
+// identity returns the permutation 0, 1, ..., n-1.
+func identity(n int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// bucket stably reorders the positions in order by keys[position], where every key is below size.
+func bucket(keys []int, size int, order []int) []int {
+	start := make([]int, size+1)
+	for _, p := range order {
+		start[keys[p]+1]++
+	}
+	for k := 0; k < size; k++ {
+		start[k+1] += start[k]
+	}
+
+	sorted := make([]int, len(order))
+	for _, p := range order {
+		sorted[start[keys[p]]] = p
+		start[keys[p]]++
+	}
+	return sorted
+}
@@ -0,0 +1,262 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func sparseExample() *MatrixStruct {
+	m, _ := Matrix(4, 5, []float64{
+		1, 0, 0, 2, 0,
+		0, 0, 0, 0, 0,
+		0, 3, 0, 0, 4,
+		5, 0, 6, 0, 0,
+	})
+	return m
+}
+
+func TestSparseConversions(t *testing.T) {
+	assert := assert.New(t)
+
+	m := sparseExample()
+	a := m.CSR()
+	assert.Equal([]int{0, 2, 2, 4, 6}, a.RowPointers)
+	assert.Equal([]int{0, 3, 1, 4, 0, 2}, a.ColumnIndices)
+	assert.Equal([]float64{1, 2, 3, 4, 5, 6}, a.Values)
+	assert.Equal(6, a.NonZeros())
+	assert.Equal(m.Elements, a.Dense().Elements)
+
+	c := m.CSC()
+	assert.Equal([]int{0, 2, 3, 4, 5, 6}, c.ColumnPointers)
+	assert.Equal([]int{0, 3, 2, 3, 0, 2}, c.RowIndices)
+	assert.Equal([]float64{1, 5, 3, 6, 2, 4}, c.Values)
+	assert.Equal(m.Elements, c.Dense().Elements)
+	assert.Equal(a, c.CSR())
+	assert.Equal(c, a.CSC())
+
+	coo := m.COO()
+	assert.Equal(m.Elements, coo.Dense().Elements)
+	assert.Equal(a, coo.CSR())
+	assert.Equal(c, coo.CSC())
+	assert.Equal(m.Elements, c.COO().Dense().Elements)
+
+	for y := 0; y < m.Rows; y++ {
+		for x := 0; x < m.Columns; x++ {
+			for _, s := range []Interface{m, a, c, coo} {
+				value, err := s.GetValue(y, x)
+				assert.Nil(err)
+				assert.Equal(m.Elements[y*m.Columns+x], value)
+			}
+		}
+	}
+	for _, s := range []Interface{a, c, coo} {
+		_, err := s.GetValue(4, 0)
+		assert.NotNil(err)
+		_, err = s.GetValue(0, -1)
+		assert.NotNil(err)
+	}
+}
+
+func TestCOO(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := NewCOO(3, 3)
+	assert.Nil(err)
+	assert.Nil(c.Append(2, 1, 1))
+	assert.Nil(c.Append(0, 2, 2))
+	assert.Nil(c.Append(2, 1, 3))
+	assert.Nil(c.Append(0, 0, 4))
+	assert.NotNil(c.Append(3, 0, 1))
+
+	value, err := c.GetValue(2, 1)
+	assert.Nil(err)
+	assert.Equal(float64(4), value)
+
+	a := c.CSR()
+	assert.Equal([]int{0, 2, 2, 3}, a.RowPointers)
+	assert.Equal([]int{0, 2, 1}, a.ColumnIndices)
+	assert.Equal([]float64{4, 2, 4}, a.Values)
+
+	assert.Equal([]float64{4, 0, 2, 0, 0, 0, 0, 4, 0}, c.Dense().Elements)
+	assert.Equal(c.Dense().Transpose().Elements, c.Transpose().Dense().Elements)
+
+	_, err = NewCOO(-1, 2)
+	assert.NotNil(err)
+}
+
+func TestNewCSR(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := NewCSR(2, 3, []int{0, 1, 3}, []int{2, 0, 1}, []float64{1, 2, 3})
+	assert.Nil(err)
+	assert.Equal([]float64{0, 0, 1, 2, 3, 0}, a.Dense().Elements)
+
+	_, err = NewCSR(2, 3, []int{0, 1}, []int{2}, []float64{1})
+	assert.NotNil(err)
+	_, err = NewCSR(2, 3, []int{0, 2, 1}, []int{0, 1}, []float64{1, 2})
+	assert.NotNil(err)
+	_, err = NewCSR(2, 3, []int{0, 2, 2}, []int{1, 1}, []float64{1, 2})
+	assert.NotNil(err)
+	_, err = NewCSR(2, 3, []int{0, 1, 1}, []int{3}, []float64{1})
+	assert.NotNil(err)
+
+	c, err := NewCSC(3, 2, []int{0, 1, 3}, []int{2, 0, 1}, []float64{1, 2, 3})
+	assert.Nil(err)
+	assert.Equal(a.Dense().Transpose().Elements, c.Dense().Elements)
+}
+
+func TestSparseTranspose(t *testing.T) {
+	assert := assert.New(t)
+
+	m := sparseExample()
+	expected := m.Transpose().Elements
+	for _, s := range []Interface{m, m.CSR(), m.CSC(), m.COO()} {
+		transposed := s.T()
+		rows, columns := transposed.Dims()
+		assert.Equal(5, rows)
+		assert.Equal(4, columns)
+		assert.Equal(expected, transposed.Dense().Elements)
+	}
+}
+
+func TestSparseMulVec(t *testing.T) {
+	assert := assert.New(t)
+
+	m := sparseExample()
+	x := []float64{1, -1, 2, 0.5, 3}
+	expected := make([]float64, 4)
+	m.MulVec(expected, x)
+	assert.Equal([]float64{2, 0, 9, 17}, expected)
+
+	for _, s := range []Interface{m.CSR(), m.CSC(), m.COO()} {
+		dst := []float64{9, 9, 9, 9}
+		s.MulVec(dst, x)
+		assert.Equal(expected, dst)
+	}
+}
+
+func TestSparseAdd(t *testing.T) {
+	assert := assert.New(t)
+
+	m := sparseExample()
+	n, _ := Matrix(4, 5, []float64{
+		-1, 1, 0, 0, 0,
+		0, 0, 7, 0, 0,
+		0, 0, 0, 0, 1,
+		0, 0, 0, 0, 2,
+	})
+	expected, _ := m.Add(n)
+
+	sum, err := m.CSR().Add(n.CSR())
+	assert.Nil(err)
+	assert.Equal(expected.Elements, sum.Dense().Elements)
+	assert.Equal(9, sum.NonZeros())
+
+	cscSum, err := m.CSC().Add(n.CSC())
+	assert.Nil(err)
+	assert.Equal(expected.Elements, cscSum.Dense().Elements)
+
+	cooSum, err := m.COO().Add(n.COO())
+	assert.Nil(err)
+	assert.Equal(expected.Elements, cooSum.Dense().Elements)
+	assert.Equal(9, cooSum.CSR().NonZeros())
+
+	_, err = m.CSR().Add(m.Transpose().CSR())
+	assert.NotNil(err)
+	_, err = m.CSC().Add(m.Transpose().CSC())
+	assert.NotNil(err)
+	_, err = m.COO().Add(m.Transpose().COO())
+	assert.NotNil(err)
+}
+
+func TestSparseMultiply(t *testing.T) {
+	assert := assert.New(t)
+
+	m := sparseExample()
+	d, _ := Matrix(5, 2, []float64{1, 2, 0, 1, -1, 0, 3, 0, 0, 4})
+	expected, _ := m.Multiply(d)
+
+	product, err := m.CSR().Multiply(d)
+	assert.Nil(err)
+	assert.Equal(expected.Elements, product.Elements)
+
+	product, err = m.CSC().Multiply(d)
+	assert.Nil(err)
+	assert.Equal(expected.Elements, product.Elements)
+
+	sparse, err := m.CSR().MultiplySparse(d.CSR())
+	assert.Nil(err)
+	assert.Equal(expected.Elements, sparse.Dense().Elements)
+	_, err = NewCSR(sparse.Rows, sparse.Columns, sparse.RowPointers, sparse.ColumnIndices, sparse.Values)
+	assert.Nil(err)
+
+	cscProduct, err := m.CSC().MultiplySparse(d.CSC())
+	assert.Nil(err)
+	assert.Equal(expected.Elements, cscProduct.Dense().Elements)
+
+	product, err = m.COO().Multiply(d)
+	assert.Nil(err)
+	assert.Equal(expected.Elements, product.Elements)
+
+	cooProduct, err := m.COO().MultiplySparse(d.COO())
+	assert.Nil(err)
+	assert.Equal(expected.Elements, cooProduct.Dense().Elements)
+
+	gram, err := m.CSR().MultiplySparse(m.CSR().Transpose())
+	assert.Nil(err)
+	expected, _ = m.Multiply(m.Transpose())
+	assert.Equal(expected.Elements, gram.Dense().Elements)
+	assert.True(gram.IsSymmetric())
+
+	_, err = m.CSR().Multiply(m)
+	assert.NotNil(err)
+	_, err = m.CSR().MultiplySparse(m.CSR())
+	assert.NotNil(err)
+	_, err = m.CSC().Multiply(m)
+	assert.NotNil(err)
+	_, err = m.CSC().MultiplySparse(m.CSC())
+	assert.NotNil(err)
+	_, err = m.COO().Multiply(m)
+	assert.NotNil(err)
+	_, err = m.COO().MultiplySparse(m.COO())
+	assert.NotNil(err)
+
+	scaled := m.CSR().ScalarMultiply(2)
+	assert.Equal(m.ScalarMultiply(2).Elements, scaled.Dense().Elements)
+}
+
+func TestSparseSolvers(t *testing.T) {
+	assert := assert.New(t)
+
+	// The iterative solvers and preconditioners take sparse matrices directly.
+	A := poisson(8).CSR()
+	b := sequence(64)
+	ic, err := NewIC0(A)
+	assert.Nil(err)
+	result, err := CG(A, b, &IterativeSettings{Preconditioner: ic})
+	assert.Nil(err)
+	assertSolves(assert, A.Dense(), b, result)
+}
+
+func BenchmarkCSRMulVec(b *testing.B) {
+	A := poisson(30).CSR()
+	x := sequence(900)
+	dst := make([]float64, 900)
+	for n := 0; n < b.N; n++ {
+		A.MulVec(dst, x)
+	}
+}
+
+func BenchmarkCSRMultiplySparse(b *testing.B) {
+	A := poisson(20).CSR()
+	for n := 0; n < b.N; n++ {
+		_, _ = A.MultiplySparse(A)
+	}
+}
+
+func BenchmarkCOOToCSR(b *testing.B) {
+	c := poisson(20).COO()
+	for n := 0; n < b.N; n++ {
+		_ = c.CSR()
+	}
+}
@@ -0,0 +1,221 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// SparseCholeskySymbolic is the symbolic analysis of a sparse Cholesky decomposition, which can be reused for any matrix with the same pattern.
+type SparseCholeskySymbolic struct {
+	n              int
+	perm, inverse  []int
+	parent         []int
+	columnPointers []int
+	pattern        *CSR
+}
+
+// SparseCholesky is the decomposition P*A*P^T = L*L^T of a sparse symmetric positive definite matrix.
+type SparseCholesky struct {
+	symbolic *SparseCholeskySymbolic
+	l        *CSC
+}
+
+// AnalyzeSparseCholesky will compute the symbolic analysis of the pattern of a sparse symmetric matrix with the given ordering.
+func AnalyzeSparseCholesky(A Interface, ordering Ordering) (*SparseCholeskySymbolic, error) {
+	a, err := squareCSR(A)
+	if err != nil {
+		return nil, err
+	}
+	perm, err := a.Order(ordering)
+	if err != nil {
+		return nil, err
+	}
+
+	n := a.Rows
+	s := &SparseCholeskySymbolic{n: n, perm: perm, inverse: inversePermutation(perm), pattern: a}
+	c := a.permuteSymmetric(s.inverse)
+	s.parent = eliminationTree(c)
+
+	// Row k of L has the pattern given by the reach of row k of C in the elimination tree, counting those gives the column counts.
+	counts := make([]int, n)
+	stack := make([]int, n)
+	mark := make([]int, n)
+	for i := range mark {
+		mark[i] = -1
+	}
+	for k := 0; k < n; k++ {
+		top := ereach(c, k, s.parent, stack, mark)
+		for _, j := range stack[top:] {
+			counts[j]++
+		}
+		counts[k]++
+	}
+
+	s.columnPointers = make([]int, n+1)
+	for j := 0; j < n; j++ {
+		s.columnPointers[j+1] = s.columnPointers[j] + counts[j]
+	}
+	return s, nil
+}
+
+// NonZeros will return the number of nonzero elements in the Cholesky factor, which predicts the memory and time that Factorize will need.
+func (s *SparseCholeskySymbolic) NonZeros() int {
+	return s.columnPointers[s.n]
+}
+
+// Factorize will compute the numeric Cholesky decomposition of A, which must have the same pattern as the matrix that was analysed.
+func (s *SparseCholeskySymbolic) Factorize(A Interface) (*SparseCholesky, error) {
+	a := csrOf(A)
+	if !samePattern(a, s.pattern) {
+		return nil, errors.New("Matrix pattern does not match the symbolic analysis")
+	}
+	if !a.IsSymmetric() {
+		return nil, errors.New("Not a symmetric matrix")
+	}
+
+	n := s.n
+	c := a.permuteSymmetric(s.inverse)
+	nnz := s.NonZeros()
+	rows := make([]int, nnz)
+	values := make([]float64, nnz)
+	next := append([]int(nil), s.columnPointers[:n]...)
+
+	x := make([]float64, n)
+	stack := make([]int, n)
+	mark := make([]int, n)
+	for i := range mark {
+		mark[i] = -1
+	}
+
+	// Up-looking factorization, one row of L at a time.
+	for k := 0; k < n; k++ {
+		top := ereach(c, k, s.parent, stack, mark)
+		x[k] = 0
+		for p := c.RowPointers[k]; p < c.RowPointers[k+1]; p++ {
+			if j := c.ColumnIndices[p]; j <= k {
+				x[j] = c.Values[p]
+			}
+		}
+
+		// Solve L[0:k, 0:k] * l = x for row k of L, one column of the pattern at a time.
+		d := x[k]
+		x[k] = 0
+		for _, i := range stack[top:] {
+			lki := x[i] / values[s.columnPointers[i]]
+			x[i] = 0
+			for p := s.columnPointers[i] + 1; p < next[i]; p++ {
+				x[rows[p]] -= values[p] * lki
+			}
+			d -= lki * lki
+			rows[next[i]] = k
+			values[next[i]] = lki
+			next[i]++
+		}
+
+		if d <= 0 || math.IsNaN(d) {
+			return nil, errors.New("Matrix is not positive definite")
+		}
+		rows[next[k]] = k
+		values[next[k]] = math.Sqrt(d)
+		next[k]++
+	}
+
+	l := &CSC{Rows: n, Columns: n, ColumnPointers: s.columnPointers, RowIndices: rows, Values: values}
+	return &SparseCholesky{symbolic: s, l: l}, nil
+}
+
+// SparseCholesky will return the Cholesky decomposition of a sparse symmetric positive definite matrix.
+func (a *CSR) SparseCholesky(ordering Ordering) (*SparseCholesky, error) {
+	s, err := AnalyzeSparseCholesky(a, ordering)
+	if err != nil {
+		return nil, err
+	}
+	return s.Factorize(a)
+}
+
+// Factor will return the lower triangular factor L and the permutation, where perm[k] is the original index of row k of P*A*P^T.
+func (f *SparseCholesky) Factor() (L *CSC, perm []int) {
+	return f.l, f.symbolic.perm
+}
+
+// Solve will return the solution of A*x = b using the factors, with a forward and a backward substitution.
+func (f *SparseCholesky) Solve(b []float64) ([]float64, error) {
+	n := f.symbolic.n
+	if len(b) != n {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	l := f.l
+	y := make([]float64, n)
+	for k, i := range f.symbolic.perm {
+		y[k] = b[i]
+	}
+
+	for j := 0; j < n; j++ {
+		y[j] /= l.Values[l.ColumnPointers[j]]
+		for p := l.ColumnPointers[j] + 1; p < l.ColumnPointers[j+1]; p++ {
+			y[l.RowIndices[p]] -= l.Values[p] * y[j]
+		}
+	}
+	for j := n - 1; j >= 0; j-- {
+		for p := l.ColumnPointers[j] + 1; p < l.ColumnPointers[j+1]; p++ {
+			y[j] -= l.Values[p] * y[l.RowIndices[p]]
+		}
+		y[j] /= l.Values[l.ColumnPointers[j]]
+	}
+
+	x := make([]float64, n)
+	for k, i := range f.symbolic.perm {
+		x[i] = y[k]
+	}
+	return x, nil
+}
+
+// eliminationTree returns the parent of every node in the elimination tree of a symmetric matrix, or -1 for a root.
+func eliminationTree(c *CSR) []int {
+	n := c.Rows
+	// Path compression on the lower triangle keeps the ancestor walks short.
+	parent := make([]int, n)
+	ancestor := make([]int, n)
+	for k := 0; k < n; k++ {
+		parent[k] = -1
+		ancestor[k] = -1
+		for p := c.RowPointers[k]; p < c.RowPointers[k+1]; p++ {
+			for i := c.ColumnIndices[p]; i != -1 && i < k; {
+				next := ancestor[i]
+				ancestor[i] = k
+				if next == -1 {
+					parent[i] = k
+				}
+				i = next
+			}
+		}
+	}
+	return parent
+}
+
+// ereach writes the pattern of row k of the Cholesky factor into stack[top:], with every node before its ancestors, and returns top.
+func ereach(c *CSR, k int, parent, stack, mark []int) int {
+	n := c.Rows
+	// Nodes are marked with k so that mark does not need clearing between rows.
+	top := n
+	mark[k] = k
+	path := make([]int, 0, 16)
+	for p := c.RowPointers[k]; p < c.RowPointers[k+1]; p++ {
+		i := c.ColumnIndices[p]
+		if i > k {
+			continue
+		}
+		path = path[:0]
+		for ; mark[i] != k; i = parent[i] {
+			path = append(path, i)
+			mark[i] = k
+		}
+		for len(path) > 0 {
+			top--
+			stack[top] = path[len(path)-1]
+			path = path[:len(path)-1]
+		}
+	}
+	return top
+}
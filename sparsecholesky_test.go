@@ -0,0 +1,77 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSparseCholesky(t *testing.T) {
+	assert := assert.New(t)
+
+	A := poisson(8)
+	b := sequence(A.Rows)
+	expected, _ := A.Solve(&MatrixStruct{Rows: len(b), Columns: 1, Capacity: len(b), Elements: b})
+
+	for _, ordering := range []Ordering{OrderingNatural, OrderingRCM, OrderingMinimumDegree} {
+		f, err := A.CSR().SparseCholesky(ordering)
+		assert.Nil(err)
+		x, err := f.Solve(b)
+		assert.Nil(err)
+		assert.InDeltaSlice(expected.Elements, x, 1e-10)
+
+		// P*A*P^T = L*L^T
+		L, perm := f.Factor()
+		dense := L.Dense()
+		product, _ := dense.Multiply(dense.Transpose())
+		for i := 0; i < A.Rows; i++ {
+			for j := 0; j < A.Rows; j++ {
+				assert.InDelta(A.Elements[perm[i]*A.Rows+perm[j]], product.Elements[i*A.Rows+j], 1e-10)
+			}
+		}
+	}
+
+	f, _ := A.CSR().SparseCholesky(OrderingMinimumDegree)
+	_, err := f.Solve(b[:3])
+	assert.EqualError(err, "matrix dimensions do not agree")
+}
+
+func TestSparseCholeskyReuse(t *testing.T) {
+	assert := assert.New(t)
+
+	A := poisson(6)
+	s, err := AnalyzeSparseCholesky(A, OrderingMinimumDegree)
+	assert.Nil(err)
+
+	// Shifting the diagonal keeps the pattern, so the analysis can be reused.
+	shifted := A.CSR()
+	for _, p := range shifted.diagonal() {
+		shifted.Values[p] += 2
+	}
+	f, err := s.Factorize(shifted)
+	assert.Nil(err)
+	b := sequence(A.Rows)
+	x, _ := f.Solve(b)
+	residual := make([]float64, len(b))
+	shifted.MulVec(residual, x)
+	assert.InDeltaSlice(b, residual, 1e-10)
+
+	_, err = s.Factorize(poisson(5))
+	assert.EqualError(err, "Matrix pattern does not match the symbolic analysis")
+
+	indefinite := A.CSR().ScalarMultiply(-1)
+	_, err = s.Factorize(indefinite)
+	assert.EqualError(err, "Matrix is not positive definite")
+
+	nonsymmetric := convectionDiffusion(6).CSR()
+	_, err = s.Factorize(nonsymmetric)
+	assert.EqualError(err, "Not a symmetric matrix")
+}
+
+func BenchmarkSparseCholesky(b *testing.B) {
+	A := poisson(30).CSR()
+	s, _ := AnalyzeSparseCholesky(A, OrderingMinimumDegree)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Factorize(A)
+	}
+}
@@ -0,0 +1,257 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// sparseLUPivotTolerance is the fraction of the largest candidate that the diagonal element needs to be kept as the pivot.
+const sparseLUPivotTolerance = 0.1
+
+// SparseLUSymbolic is the symbolic analysis of a sparse LU decomposition, holding the fill reducing column ordering and the pattern it was computed for.
+type SparseLUSymbolic struct {
+	// The row order, and so the pattern of the factors, is only known once the values are.
+	n       int
+	q       []int
+	pattern *CSR
+}
+
+// SparseLU is the decomposition P*A*Q = L*U of a sparse square matrix, where P permutes the rows for stability and Q permutes the columns to reduce fill.
+type SparseLU struct {
+	l, u *CSC
+	pinv []int
+	q    []int
+}
+
+// AnalyzeSparseLU will compute the symbolic analysis of the pattern of a sparse square matrix with the given column ordering.
+func AnalyzeSparseLU(A Interface, ordering Ordering) (*SparseLUSymbolic, error) {
+	a, err := squareCSR(A)
+	if err != nil {
+		return nil, err
+	}
+	q, err := a.Order(ordering)
+	if err != nil {
+		return nil, err
+	}
+	return &SparseLUSymbolic{n: a.Rows, q: q, pattern: a}, nil
+}
+
+// Factorize will compute the numeric LU decomposition of A, which must have the same pattern as the matrix that was analysed.
+func (s *SparseLUSymbolic) Factorize(A Interface) (*SparseLU, error) {
+	a := csrOf(A)
+	if !samePattern(a, s.pattern) {
+		return nil, errors.New("Matrix pattern does not match the symbolic analysis")
+	}
+
+	n := s.n
+	c := a.CSC()
+	l := &CSC{Rows: n, Columns: n, ColumnPointers: make([]int, n+1)}
+	u := &CSC{Rows: n, Columns: n, ColumnPointers: make([]int, n+1)}
+	pinv := make([]int, n)
+	for i := range pinv {
+		pinv[i] = -1
+	}
+
+	// The left-looking algorithm of Gilbert and Peierls takes time proportional to the number of floating point operations.
+	x := make([]float64, n)
+	xi := make([]int, n)
+	w := newReachWork(n)
+
+	for k := 0; k < n; k++ {
+		l.ColumnPointers[k] = len(l.Values)
+		u.ColumnPointers[k] = len(u.Values)
+		column := s.q[k]
+
+		// x = L \ A(:, column), where L only has the columns found so far.
+		top := w.reach(l, c, column, pinv, xi, k)
+		for _, i := range xi[top:] {
+			x[i] = 0
+		}
+		for p := c.ColumnPointers[column]; p < c.ColumnPointers[column+1]; p++ {
+			x[c.RowIndices[p]] = c.Values[p]
+		}
+		for _, j := range xi[top:] {
+			J := pinv[j]
+			if J < 0 {
+				continue
+			}
+			for p := l.ColumnPointers[J] + 1; p < l.ColumnPointers[J+1]; p++ {
+				x[l.RowIndices[p]] -= l.Values[p] * x[j]
+			}
+		}
+
+		// Rows that already have a pivot belong to U, the largest of the rest is the pivot candidate.
+		pivotRow := -1
+		largest := float64(-1)
+		for _, i := range xi[top:] {
+			if pinv[i] < 0 {
+				if v := math.Abs(x[i]); v > largest {
+					largest = v
+					pivotRow = i
+				}
+			} else {
+				u.RowIndices = append(u.RowIndices, pinv[i])
+				u.Values = append(u.Values, x[i])
+			}
+		}
+		if pivotRow < 0 || largest <= 0 || math.IsNaN(largest) {
+			return nil, errors.New("Matrix is singular")
+		}
+		// Keeping the diagonal preserves the fill reducing ordering while still bounding the growth of the factors.
+		if pinv[column] < 0 && math.Abs(x[column]) >= sparseLUPivotTolerance*largest {
+			pivotRow = column
+		}
+
+		pivot := x[pivotRow]
+		u.RowIndices = append(u.RowIndices, k)
+		u.Values = append(u.Values, pivot)
+		pinv[pivotRow] = k
+		l.RowIndices = append(l.RowIndices, pivotRow)
+		l.Values = append(l.Values, 1)
+		for _, i := range xi[top:] {
+			if pinv[i] < 0 {
+				l.RowIndices = append(l.RowIndices, i)
+				l.Values = append(l.Values, x[i]/pivot)
+			}
+			x[i] = 0
+		}
+	}
+	l.ColumnPointers[n] = len(l.Values)
+	u.ColumnPointers[n] = len(u.Values)
+
+	// L was built with original row numbers, renumber them into pivot order and sort both factors.
+	for p, i := range l.RowIndices {
+		l.RowIndices[p] = pinv[i]
+	}
+	return &SparseLU{l: l.CSR().CSC(), u: u.CSR().CSC(), pinv: pinv, q: s.q}, nil
+}
+
+// SparseLU will return the LU decomposition of a sparse square matrix, combining the symbolic analysis and the numeric factorization.
+func (a *CSR) SparseLU(ordering Ordering) (*SparseLU, error) {
+	s, err := AnalyzeSparseLU(a, ordering)
+	if err != nil {
+		return nil, err
+	}
+	return s.Factorize(a)
+}
+
+// Factors will return L and U along with the permutations, where row i of A is row rowPerm[i] of P*A and column k of A*Q is column colPerm[k] of A.
+func (f *SparseLU) Factors() (L, U *CSC, rowPerm, colPerm []int) {
+	return f.l, f.u, f.pinv, f.q
+}
+
+// Solve will return the solution of A*x = b using the factors.
+func (f *SparseLU) Solve(b []float64) ([]float64, error) {
+	n := len(f.pinv)
+	if len(b) != n {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	y := make([]float64, n)
+	for i, k := range f.pinv {
+		y[k] = b[i]
+	}
+
+	l, u := f.l, f.u
+	for j := 0; j < n; j++ {
+		for p := l.ColumnPointers[j] + 1; p < l.ColumnPointers[j+1]; p++ {
+			y[l.RowIndices[p]] -= l.Values[p] * y[j]
+		}
+	}
+	for j := n - 1; j >= 0; j-- {
+		last := u.ColumnPointers[j+1] - 1
+		y[j] /= u.Values[last]
+		for p := u.ColumnPointers[j]; p < last; p++ {
+			y[u.RowIndices[p]] -= u.Values[p] * y[j]
+		}
+	}
+
+	x := make([]float64, n)
+	for k, j := range f.q {
+		x[j] = y[k]
+	}
+	return x, nil
+}
+
+// Solve will return the solution of A*x = b for a sparse square matrix using the sparse Cholesky or LU decomposition.
+func (a *CSR) Solve(b []float64) ([]float64, error) {
+	// A symmetric matrix that turns out to be indefinite falls back to LU.
+	if a.IsSymmetric() {
+		if f, err := a.SparseCholesky(OrderingMinimumDegree); err == nil {
+			return f.Solve(b)
+		}
+	}
+
+	f, err := a.SparseLU(OrderingMinimumDegree)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b)
+}
+
+// reachWork holds the scratch space for the depth first searches of the sparse LU decomposition.
+type reachWork struct {
+	mark   []int
+	stack  []int
+	pstack []int
+}
+
+func newReachWork(n int) *reachWork {
+	w := &reachWork{mark: make([]int, n), stack: make([]int, n), pstack: make([]int, n)}
+	for i := range w.mark {
+		w.mark[i] = -1
+	}
+	return w
+}
+
+// reach writes the rows that are nonzero in L \ A(:, column) into xi[top:] in topological order and returns top.
+func (w *reachWork) reach(l *CSC, c *CSC, column int, pinv, xi []int, k int) int {
+	// A row i with a pivot leads to the rows of column pinv[i] of L, and marks hold the step k so they need no clearing.
+	n := len(xi)
+	top := n
+	for p := c.ColumnPointers[column]; p < c.ColumnPointers[column+1]; p++ {
+		if i := c.RowIndices[p]; w.mark[i] != k {
+			top = w.dfs(l, i, pinv, xi, top, k)
+		}
+	}
+	return top
+}
+
+// dfs is an iterative depth first search from row j, appending each node to xi once all of its descendants have been.
+func (w *reachWork) dfs(l *CSC, j int, pinv, xi []int, top, k int) int {
+	head := 0
+	w.stack[0] = j
+	for head >= 0 {
+		j = w.stack[head]
+		J := pinv[j]
+		if w.mark[j] != k {
+			w.mark[j] = k
+			if J >= 0 {
+				w.pstack[head] = l.ColumnPointers[J]
+			}
+		}
+
+		done := true
+		if J >= 0 {
+			end := l.ColumnPointers[J+1]
+			for p := w.pstack[head]; p < end; p++ {
+				i := l.RowIndices[p]
+				if w.mark[i] == k {
+					continue
+				}
+				w.pstack[head] = p + 1
+				head++
+				w.stack[head] = i
+				done = false
+				break
+			}
+		}
+
+		if done {
+			head--
+			top--
+			xi[top] = j
+		}
+	}
+	return top
+}
@@ -0,0 +1,102 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSparseLU(t *testing.T) {
+	assert := assert.New(t)
+
+	A := convectionDiffusion(8)
+	b := sequence(A.Rows)
+	expected, _ := A.Solve(&MatrixStruct{Rows: len(b), Columns: 1, Capacity: len(b), Elements: b})
+
+	for _, ordering := range []Ordering{OrderingNatural, OrderingRCM, OrderingMinimumDegree} {
+		f, err := A.CSR().SparseLU(ordering)
+		assert.Nil(err)
+		x, err := f.Solve(b)
+		assert.Nil(err)
+		assert.InDeltaSlice(expected.Elements, x, 1e-10)
+
+		// P*A*Q = L*U
+		L, U, rowPerm, colPerm := f.Factors()
+		product, _ := L.Dense().Multiply(U.Dense())
+		for i := 0; i < A.Rows; i++ {
+			for k := 0; k < A.Rows; k++ {
+				assert.InDelta(A.Elements[i*A.Rows+colPerm[k]], product.Elements[rowPerm[i]*A.Rows+k], 1e-10)
+			}
+		}
+	}
+
+	f, _ := A.CSR().SparseLU(OrderingNatural)
+	_, err := f.Solve(b[:3])
+	assert.EqualError(err, "matrix dimensions do not agree")
+}
+
+func TestSparseLUPivoting(t *testing.T) {
+	assert := assert.New(t)
+
+	// The zero diagonal forces row interchanges.
+	A, _ := Matrix(4, 4, []float64{
+		0, 2, 0, 1,
+		3, 0, 1, 0,
+		0, 1, 0, 4,
+		1, 0, 5, 0,
+	})
+	b := []float64{1, 2, 3, 4}
+	expected, _ := A.Solve(&MatrixStruct{Rows: 4, Columns: 1, Capacity: 4, Elements: b})
+	for _, ordering := range []Ordering{OrderingNatural, OrderingMinimumDegree} {
+		f, err := A.CSR().SparseLU(ordering)
+		assert.Nil(err)
+		x, _ := f.Solve(b)
+		assert.InDeltaSlice(expected.Elements, x, 1e-12)
+	}
+
+	singular, _ := Matrix(3, 3, []float64{
+		1, 2, 0,
+		2, 4, 0,
+		0, 0, 1,
+	})
+	_, err := singular.CSR().SparseLU(OrderingNatural)
+	assert.EqualError(err, "Matrix is singular")
+}
+
+func TestSparseLUReuse(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := AnalyzeSparseLU(convectionDiffusion(6), OrderingMinimumDegree)
+	assert.Nil(err)
+	for _, A := range []*MatrixStruct{convectionDiffusion(6), poisson(6)} {
+		f, err := s.Factorize(A)
+		assert.Nil(err)
+		b := sequence(A.Rows)
+		x, _ := f.Solve(b)
+		expected, _ := A.Solve(&MatrixStruct{Rows: len(b), Columns: 1, Capacity: len(b), Elements: b})
+		assert.InDeltaSlice(expected.Elements, x, 1e-10)
+	}
+
+	_, err = s.Factorize(poisson(5))
+	assert.EqualError(err, "Matrix pattern does not match the symbolic analysis")
+}
+
+func TestCSRSolve(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, A := range []*MatrixStruct{poisson(6), poisson(6).ScalarMultiply(-1), convectionDiffusion(6)} {
+		b := sequence(A.Rows)
+		x, err := A.CSR().Solve(b)
+		assert.Nil(err)
+		expected, _ := A.Solve(&MatrixStruct{Rows: len(b), Columns: 1, Capacity: len(b), Elements: b})
+		assert.InDeltaSlice(expected.Elements, x, 1e-10)
+	}
+}
+
+func BenchmarkSparseLU(b *testing.B) {
+	A := convectionDiffusion(30).CSR()
+	s, _ := AnalyzeSparseLU(A, OrderingMinimumDegree)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Factorize(A)
+	}
+}
@@ -0,0 +1,224 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// SVDKind selects which factors are computed by the singular value decomposition.
+type SVDKind int
+
+const (
+	// SVDFull computes U as an MxM matrix, Sigma as an MxN matrix and V^T as an NxN matrix.
+	SVDFull SVDKind = iota
+	// SVDThin computes U as an MxK matrix, Sigma as a KxK matrix and V^T as a KxN matrix where K is the shortest dimension.
+	SVDThin
+)
+
+// svdMaxSweeps limits the number of one-sided Jacobi sweeps, convergence normally takes fewer than ten.
+const svdMaxSweeps = 75
+
+// SVD will return the singular value decomposition m = U*Sigma*V^T, or an error if the Jacobi rotations do not converge.
+func (m MatrixStruct) SVD(kind SVDKind) (U, Sigma, VT *MatrixStruct, err error) {
+	if kind != SVDFull && kind != SVDThin {
+		return nil, nil, nil, errors.New("Unknown SVD kind")
+	}
+
+	u, s, v, err := m.svd(true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	k := len(s)
+
+	if kind == SVDThin {
+		Sigma, _ = Zeros(k, k)
+		for i, value := range s {
+			Sigma.Elements[i*k+i] = value
+		}
+		return u, Sigma, v.Transpose(), nil
+	}
+
+	Sigma, _ = Zeros(m.Rows, m.Columns)
+	for i, value := range s {
+		Sigma.Elements[i*m.Columns+i] = value
+	}
+	return completeBasis(u, m.Rows), Sigma, completeBasis(v, m.Columns).Transpose(), nil
+}
+
+// SingularValues will return the singular values of the matrix in non-increasing order, or an error if the rotations do not converge.
+func (m MatrixStruct) SingularValues() ([]float64, error) {
+	_, s, _, err := m.svd(false)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// svd computes the thin singular value decomposition using one-sided Jacobi rotations, returning U as MxK, the K singular values and V as NxK.
+func (m MatrixStruct) svd(vectors bool) (u *MatrixStruct, s []float64, v *MatrixStruct, err error) {
+	// Wide matrices are handled by decomposing their transpose.
+	if m.Rows < m.Columns {
+		v, s, u, err = m.Transpose().svd(vectors)
+		return
+	}
+
+	rows, cols := m.Rows, m.Columns
+	a := m.Clone()
+	w := a.Elements
+	v, _ = Eye(cols, cols)
+
+	converged := false
+	for sweep := 0; sweep < svdMaxSweeps && !converged; sweep++ {
+		rotated := false
+		for p := 0; p < cols-1; p++ {
+			for q := p + 1; q < cols; q++ {
+				alpha, beta, gamma := float64(0), float64(0), float64(0)
+				for i := 0; i < rows; i++ {
+					ap, aq := w[i*cols+p], w[i*cols+q]
+					alpha += ap * ap
+					beta += aq * aq
+					gamma += ap * aq
+				}
+
+				if gamma == 0 || math.Abs(gamma) <= epsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				sn := c * t
+
+				for i := 0; i < rows; i++ {
+					ap, aq := w[i*cols+p], w[i*cols+q]
+					w[i*cols+p] = c*ap - sn*aq
+					w[i*cols+q] = sn*ap + c*aq
+				}
+				for i := 0; i < cols; i++ {
+					vp, vq := v.Elements[i*cols+p], v.Elements[i*cols+q]
+					v.Elements[i*cols+p] = c*vp - sn*vq
+					v.Elements[i*cols+q] = sn*vp + c*vq
+				}
+			}
+		}
+		converged = !rotated
+	}
+	if !converged {
+		err = errors.New("Singular value decomposition did not converge")
+	}
+
+	s = make([]float64, cols)
+	for j := 0; j < cols; j++ {
+		sum := float64(0)
+		for i := 0; i < rows; i++ {
+			sum += w[i*cols+j] * w[i*cols+j]
+		}
+		s[j] = math.Sqrt(sum)
+	}
+
+	order := make([]int, cols)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return s[order[i]] > s[order[j]] })
+
+	sorted := make([]float64, cols)
+	for i, j := range order {
+		sorted[i] = s[j]
+	}
+
+	if !vectors {
+		return nil, sorted, nil, err
+	}
+
+	u, _ = Zeros(rows, cols)
+	vSorted, _ := Zeros(cols, cols)
+	tol := float64(rows) * epsilon * sorted[0]
+	for k, j := range order {
+		for i := 0; i < cols; i++ {
+			vSorted.Elements[i*cols+k] = v.Elements[i*cols+j]
+		}
+		if s[j] <= tol || s[j] == 0 {
+			continue
+		}
+		for i := 0; i < rows; i++ {
+			u.Elements[i*cols+k] = w[i*cols+j] / s[j]
+		}
+	}
+
+	return completeBasis(u, cols), sorted, vSorted, err
+}
+
+// completeBasis returns a matrix with the given number of orthonormal columns that starts with the unit columns of u.
+func completeBasis(u *MatrixStruct, columns int) *MatrixStruct {
+	rows := u.Rows
+	basis, _ := Zeros(rows, columns)
+	b := basis.Elements
+	filled := make([]bool, columns)
+
+	for j := 0; j < u.Columns && j < columns; j++ {
+		norm := float64(0)
+		for i := 0; i < rows; i++ {
+			norm += u.Elements[i*u.Columns+j] * u.Elements[i*u.Columns+j]
+		}
+		if norm < 0.5 {
+			continue
+		}
+		for i := 0; i < rows; i++ {
+			b[i*columns+j] = u.Elements[i*u.Columns+j]
+		}
+		filled[j] = true
+	}
+
+	// Zero columns and any additional columns are filled in from the standard basis vectors.
+	candidate := 0
+	x := make([]float64, rows)
+	for j := 0; j < columns; j++ {
+		if filled[j] {
+			continue
+		}
+		for ; candidate < rows; candidate++ {
+			for i := range x {
+				x[i] = 0
+			}
+			x[candidate] = 1
+
+			// Two passes of Gram-Schmidt keep the new column orthogonal to working precision.
+			for pass := 0; pass < 2; pass++ {
+				for k := 0; k < columns; k++ {
+					if !filled[k] {
+						continue
+					}
+					dot := float64(0)
+					for i := 0; i < rows; i++ {
+						dot += b[i*columns+k] * x[i]
+					}
+					for i := 0; i < rows; i++ {
+						x[i] -= dot * b[i*columns+k]
+					}
+				}
+			}
+
+			norm := float64(0)
+			for _, value := range x {
+				norm += value * value
+			}
+			norm = math.Sqrt(norm)
+			if norm > 0.5 {
+				for i := 0; i < rows; i++ {
+					b[i*columns+j] = x[i] / norm
+				}
+				filled[j] = true
+				candidate++
+				break
+			}
+		}
+	}
+
+	return basis
+}
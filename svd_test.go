@@ -0,0 +1,91 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestSVD(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(4, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	assert.Nil(err)
+
+	U, S, VT, err := a.SVD(SVDFull)
+	assert.Nil(err)
+	assert.Equal(U.Rows, 4)
+	assert.Equal(U.Columns, 4)
+	assert.Equal(S.Rows, 4)
+	assert.Equal(S.Columns, 3)
+	assert.Equal(VT.Rows, 3)
+	assert.Equal(VT.Columns, 3)
+
+	US, _ := U.Multiply(S)
+	A, _ := US.Multiply(VT)
+	assert.InDeltaSlice(a.Elements, A.Elements, 1e-12)
+
+	eye4, _ := Eye(4, 4)
+	UTU, _ := U.Transpose().Multiply(U)
+	assert.InDeltaSlice(eye4.Elements, UTU.Elements, 1e-12)
+
+	eye3, _ := Eye(3, 3)
+	VVT, _ := VT.Multiply(VT.Transpose())
+	assert.InDeltaSlice(eye3.Elements, VVT.Elements, 1e-12)
+
+	assert.InDelta(25.462407436036397, S.Elements[0], 1e-12)
+	assert.InDelta(1.2906616757612357, S.Elements[4], 1e-12)
+	assert.InDelta(0, S.Elements[8], 1e-12)
+
+	b := a.Transpose()
+	U, S, VT, err = b.SVD(SVDThin)
+	assert.Nil(err)
+	assert.Equal(U.Rows, 3)
+	assert.Equal(U.Columns, 3)
+	assert.Equal(S.Rows, 3)
+	assert.Equal(VT.Rows, 3)
+	assert.Equal(VT.Columns, 4)
+
+	US, _ = U.Multiply(S)
+	B, _ := US.Multiply(VT)
+	assert.InDeltaSlice(b.Elements, B.Elements, 1e-12)
+
+	_, _, _, err = a.SVD(SVDKind(5))
+	assert.NotNil(err)
+}
+
+func BenchmarkSVD(b *testing.B) {
+	a, _ := Matrix(4, 4, []float64{10, 4, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12, 13, 1, 1, 16})
+	for n := 0; n < b.N; n++ {
+		_, _, _, _ = a.SVD(SVDFull)
+	}
+}
+
+func TestSingularValues(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(2, 2, []float64{3, 0, 0, -4})
+	assert.Nil(err)
+	s, err := a.SingularValues()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{4, 3}, s, 1e-12)
+
+	b, err := Matrix(2, 3, []float64{3, 2, 2, 2, 3, -2})
+	assert.Nil(err)
+	s, err = b.SingularValues()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{5, 3}, s, 1e-12)
+}
+
+func TestSVDNotConverged(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 2, []float64{1, math.NaN(), 3, 4, 5, 6})
+	assert.Nil(err)
+
+	_, _, _, err = a.SVD(SVDThin)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+	s, err := a.SingularValues()
+	assert.Nil(s)
+	assert.EqualError(err, "Singular value decomposition did not converge")
+}
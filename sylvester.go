@@ -0,0 +1,213 @@
+package matrix
+
+import "errors"
+
+// SolveSylvester will return the solution X of the Sylvester equation A*X + X*B = C along with the Frobenius norm of its residual.
+func SolveSylvester(A, B, C *MatrixStruct) (X *MatrixStruct, residual float64, err error) {
+	if !A.IsSquare() || !B.IsSquare() || C.Rows != A.Rows || C.Columns != B.Rows {
+		return nil, 0, errors.New("matrix dimensions do not agree")
+	}
+
+	// Bartels-Stewart has a unique solution when A and -B have no eigenvalues in common.
+	X, err = solveSchurEquation(A, B, C, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	AX, _ := A.Multiply(X)
+	XB, _ := X.Multiply(B)
+	R, _ := AX.Add(XB)
+	R, _ = R.Subtract(C)
+	return X, R.frobenius(), nil
+}
+
+// SolveLyapunov will return the solution X of the continuous Lyapunov equation A*X + X*A^T + Q = 0 along with the Frobenius norm of its residual.
+func SolveLyapunov(A, Q *MatrixStruct) (X *MatrixStruct, residual float64, err error) {
+	if !A.IsSquare() || !Q.IsSquare() || A.Rows != Q.Rows {
+		return nil, 0, errors.New("matrix dimensions do not agree")
+	}
+
+	X, err = solveSchurEquation(A, A.Transpose(), Q.ScalarMultiply(-1), false)
+	if err != nil {
+		return nil, 0, err
+	}
+	if Q.IsSymmetric() {
+		X.symmetrize()
+	}
+
+	AX, _ := A.Multiply(X)
+	XAT, _ := X.Multiply(A.Transpose())
+	R, _ := AX.Add(XAT)
+	R, _ = R.Add(Q)
+	return X, R.frobenius(), nil
+}
+
+// SolveDiscreteLyapunov will return the solution X of the discrete Lyapunov equation A*X*A^T - X + Q = 0 along with the Frobenius norm of its residual.
+func SolveDiscreteLyapunov(A, Q *MatrixStruct) (X *MatrixStruct, residual float64, err error) {
+	if !A.IsSquare() || !Q.IsSquare() || A.Rows != Q.Rows {
+		return nil, 0, errors.New("matrix dimensions do not agree")
+	}
+
+	// A unique solution exists when no product of two eigenvalues of A equals one, which is always the case for a stable A.
+	X, err = solveSchurEquation(A, A.Transpose(), Q.ScalarMultiply(-1), true)
+	if err != nil {
+		return nil, 0, err
+	}
+	if Q.IsSymmetric() {
+		X.symmetrize()
+	}
+
+	AXAT, _ := A.Multiply(X)
+	AXAT, _ = AXAT.Multiply(A.Transpose())
+	R, _ := AXAT.Subtract(X)
+	R, _ = R.Add(Q)
+	return X, R.frobenius(), nil
+}
+
+// solveSchurEquation solves A*X + X*B = C, or A*X*B - X = C when discrete is set, by transforming to the real Schur forms A = U*S*U^T and B = V*T*V^T and solving for Y = U^T*X*V.
+func solveSchurEquation(A, B, C *MatrixStruct, discrete bool) (*MatrixStruct, error) {
+	U, S, err := A.Schur()
+	if err != nil {
+		return nil, err
+	}
+	V, T, err := B.Schur()
+	if err != nil {
+		return nil, err
+	}
+
+	F, _ := U.Transpose().Multiply(C)
+	F, _ = F.Multiply(V)
+	Y, err := solveQuasiTriangular(S, T, F, discrete)
+	if err != nil {
+		return nil, err
+	}
+
+	X, _ := U.Multiply(Y)
+	X, _ = X.Multiply(V.Transpose())
+	return X, nil
+}
+
+// solveQuasiTriangular solves S*Y + Y*T = F, or S*Y*T - Y = F when discrete is set, for upper quasi triangular S and T.
+func solveQuasiTriangular(S, T, F *MatrixStruct, discrete bool) (*MatrixStruct, error) {
+	m, n := S.Rows, T.Rows
+	Y, _ := Zeros(m, n)
+
+	var rowBlocks []int
+	for i := 0; i < m; i += S.schurBlockSize(i) {
+		rowBlocks = append(rowBlocks, i)
+	}
+
+	// Block columns of Y are found from left to right and, within each, block rows from the bottom up,
+	// so every 1x1 or 2x2 block of Y only needs a local system of at most four unknowns.
+	for k := 0; k < n; {
+		q := T.schurBlockSize(k)
+
+		// G = F(:, k:k+q) minus the contribution of the block columns that are already known, Y(:, 0:k)*T(0:k, k:k+q), which the discrete equation also multiplies by S.
+		known, _ := Zeros(m, q)
+		for i := 0; i < m; i++ {
+			for c := 0; c < q; c++ {
+				sum := float64(0)
+				for j := 0; j < k; j++ {
+					sum += Y.Elements[i*n+j] * T.Elements[j*n+k+c]
+				}
+				known.Elements[i*q+c] = sum
+			}
+		}
+		if discrete {
+			known, _ = S.Multiply(known)
+		}
+		G, _ := Zeros(m, q)
+		for i := 0; i < m; i++ {
+			for c := 0; c < q; c++ {
+				G.Elements[i*q+c] = F.Elements[i*n+k+c] - known.Elements[i*q+c]
+			}
+		}
+
+		// W holds Y(:, k:k+q) for the continuous equation and Y(:, k:k+q)*T(k:k+q, k:k+q) for the discrete one, filled in from the bottom as block rows are solved.
+		W, _ := Zeros(m, q)
+		for b := len(rowBlocks) - 1; b >= 0; b-- {
+			i0 := rowBlocks[b]
+			p := S.schurBlockSize(i0)
+
+			rhs, _ := Zeros(p*q, 1)
+			for r := 0; r < p; r++ {
+				for c := 0; c < q; c++ {
+					sum := G.Elements[(i0+r)*q+c]
+					for l := i0 + p; l < m; l++ {
+						sum -= S.Elements[(i0+r)*m+l] * W.Elements[l*q+c]
+					}
+					rhs.Elements[c*p+r] = sum
+				}
+			}
+
+			// The unknowns are the p by q block stacked by column, so the continuous system is I (x) S_ii + T_kk^T (x) I and the discrete one T_kk^T (x) S_ii - I.
+			K, _ := Zeros(p*q, p*q)
+			for c := 0; c < q; c++ {
+				for d := 0; d < q; d++ {
+					t := T.Elements[(k+d)*n+k+c]
+					for r := 0; r < p; r++ {
+						for e := 0; e < p; e++ {
+							var v float64
+							if discrete {
+								v = t * S.Elements[(i0+r)*m+i0+e]
+								if c == d && r == e {
+									v--
+								}
+							} else {
+								if c == d {
+									v = S.Elements[(i0+r)*m+i0+e]
+								}
+								if r == e {
+									v += t
+								}
+							}
+							K.Elements[(c*p+r)*p*q+d*p+e] = v
+						}
+					}
+				}
+			}
+
+			f := K.luDecompose()
+			if f.singular {
+				return nil, errors.New("Equation does not have a unique solution")
+			}
+			x, err := f.solve(rhs)
+			if err != nil {
+				return nil, err
+			}
+			for r := 0; r < p; r++ {
+				for c := 0; c < q; c++ {
+					Y.Elements[(i0+r)*n+k+c] = x.Elements[c*p+r]
+				}
+			}
+
+			for r := 0; r < p; r++ {
+				for c := 0; c < q; c++ {
+					if !discrete {
+						W.Elements[(i0+r)*q+c] = x.Elements[c*p+r]
+						continue
+					}
+					sum := float64(0)
+					for d := 0; d < q; d++ {
+						sum += x.Elements[d*p+r] * T.Elements[(k+d)*n+k+c]
+					}
+					W.Elements[(i0+r)*q+c] = sum
+				}
+			}
+		}
+		k += q
+	}
+	return Y, nil
+}
+
+// symmetrize replaces the matrix with (m + m^T)/2, removing the rounding error asymmetry from a solution that should be symmetric.
+func (m MatrixStruct) symmetrize() {
+	n := m.Rows
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			mean := (m.Elements[i*n+j] + m.Elements[j*n+i]) / 2
+			m.Elements[i*n+j] = mean
+			m.Elements[j*n+i] = mean
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// trigMatrix returns a dense matrix with no structure, whose real Schur form has a mix of 1x1 and 2x2 blocks.
+func trigMatrix(rows, columns int, seed float64) *MatrixStruct {
+	m, _ := Zeros(rows, columns)
+	for i := range m.Elements {
+		m.Elements[i] = math.Sin(seed*float64(i+1) + float64(i*i))
+	}
+	return m
+}
+
+func TestSolveSylvester(t *testing.T) {
+	assert := assert.New(t)
+
+	// A has a complex pair of eigenvalues and B has a 2x2 block of its own, which exercises both block sizes.
+	A, err := Matrix(3, 3, []float64{1, -2, 0, 3, 1, 1, 0, 0, 4})
+	assert.Nil(err)
+	B, err := Matrix(2, 2, []float64{2, 1, -5, 2})
+	assert.Nil(err)
+	expected, err := Matrix(3, 2, []float64{1, -1, 2, 0.5, -3, 4})
+	assert.Nil(err)
+
+	AX, _ := A.Multiply(expected)
+	XB, _ := expected.Multiply(B)
+	C, _ := AX.Add(XB)
+
+	X, residual, err := SolveSylvester(A, B, C)
+	assert.Nil(err)
+	assert.InDeltaSlice(expected.Elements, X.Elements, 1e-12)
+	assert.InDelta(0, residual, 1e-12)
+
+	a, _ := Matrix(1, 1, []float64{1})
+	b, _ := Matrix(1, 1, []float64{-1})
+	c, _ := Matrix(1, 1, []float64{1})
+	_, _, err = SolveSylvester(a, b, c)
+	assert.NotNil(err)
+
+	_, _, err = SolveSylvester(A, B, B)
+	assert.NotNil(err)
+}
+
+func TestSolveSylvesterBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	A, B := trigMatrix(9, 9, 1.3), trigMatrix(6, 6, 0.7)
+	expected := trigMatrix(9, 6, 2.1)
+	AX, _ := A.Multiply(expected)
+	XB, _ := expected.Multiply(B)
+	C, _ := AX.Add(XB)
+
+	X, residual, err := SolveSylvester(A, B, C)
+	assert.Nil(err)
+	assert.InDeltaSlice(expected.Elements, X.Elements, 1e-9)
+	assert.InDelta(0, residual, 1e-10)
+}
+
+func BenchmarkSolveSylvester(b *testing.B) {
+	A, _ := Matrix(3, 3, []float64{1, -2, 0, 3, 1, 1, 0, 0, 4})
+	B, _ := Matrix(2, 2, []float64{2, 1, -5, 2})
+	C, _ := Matrix(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	for n := 0; n < b.N; n++ {
+		_, _, _ = SolveSylvester(A, B, C)
+	}
+}
+
+func TestSolveLyapunov(t *testing.T) {
+	assert := assert.New(t)
+
+	a, _ := Matrix(1, 1, []float64{-1})
+	q, _ := Matrix(1, 1, []float64{3})
+	X, residual, err := SolveLyapunov(a, q)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1.5}, X.Elements, 1e-15)
+	assert.InDelta(0, residual, 1e-15)
+
+	A, err := Matrix(3, 3, []float64{-1, 2, 0, -2, -1, 1, 0, 0.5, -3})
+	assert.Nil(err)
+	Q, _ := Eye(3, 3)
+	X, residual, err = SolveLyapunov(A, Q)
+	assert.Nil(err)
+	assert.InDelta(0, residual, 1e-12)
+	assert.True(X.IsSymmetric())
+	_, err = X.Cholesky()
+	assert.Nil(err)
+
+	// A nonsymmetric Q has a nonsymmetric solution, so the residual has to use X*A^T rather than (A*X)^T.
+	N, _ := Matrix(3, 3, []float64{1, 2, 0, 0, 1, 0, 0, 3, 1})
+	X, residual, err = SolveLyapunov(A, N)
+	assert.Nil(err)
+	AX, _ := A.Multiply(X)
+	XAT, _ := X.Multiply(A.Transpose())
+	R, _ := AX.Add(XAT)
+	R, _ = R.Add(N)
+	assert.InDelta(0, R.frobenius(), 1e-12)
+	assert.InDelta(R.frobenius(), residual, 1e-12)
+
+	_, _, err = SolveLyapunov(A, q)
+	assert.NotNil(err)
+}
+
+func BenchmarkSolveLyapunov(b *testing.B) {
+	A, _ := Matrix(3, 3, []float64{-1, 2, 0, -2, -1, 1, 0, 0.5, -3})
+	Q, _ := Eye(3, 3)
+	for n := 0; n < b.N; n++ {
+		_, _, _ = SolveLyapunov(A, Q)
+	}
+}
+
+func TestSolveDiscreteLyapunov(t *testing.T) {
+	assert := assert.New(t)
+
+	a, _ := Matrix(1, 1, []float64{0.5})
+	q, _ := Matrix(1, 1, []float64{3})
+	X, residual, err := SolveDiscreteLyapunov(a, q)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{4}, X.Elements, 1e-14)
+	assert.InDelta(0, residual, 1e-14)
+
+	A, err := Matrix(3, 3, []float64{0.5, 0.4, 0, -0.4, 0.5, 0.1, 0.2, 0, -0.7})
+	assert.Nil(err)
+	Q, _ := Eye(3, 3)
+	X, residual, err = SolveDiscreteLyapunov(A, Q)
+	assert.Nil(err)
+	assert.InDelta(0, residual, 1e-12)
+	assert.True(X.IsSymmetric())
+	_, err = X.Cholesky()
+	assert.Nil(err)
+
+	// Eigenvalues 2 and 0.5 multiply to one, so there is no unique solution.
+	singular, _ := Matrix(2, 2, []float64{2, 0, 0, 0.5})
+	eye, _ := Eye(2, 2)
+	_, _, err = SolveDiscreteLyapunov(singular, eye)
+	assert.NotNil(err)
+
+	B := trigMatrix(8, 8, 0.9).ScalarMultiply(0.25)
+	X, residual, err = SolveDiscreteLyapunov(B, trigMatrix(8, 8, 1.7))
+	assert.Nil(err)
+	assert.InDelta(0, residual, 1e-10)
+	assert.Equal(8, X.Rows)
+}
+
+func BenchmarkSolveDiscreteLyapunov(b *testing.B) {
+	A, _ := Matrix(3, 3, []float64{0.5, 0.4, 0, -0.4, 0.5, 0.1, 0.2, 0, -0.7})
+	Q, _ := Eye(3, 3)
+	for n := 0; n < b.N; n++ {
+		_, _, _ = SolveDiscreteLyapunov(A, Q)
+	}
+}
@@ -28,44 +28,125 @@ func (m MatrixStruct) IsLowerTriangular() bool {
 	return true
 }
 
-// TriangleInverse will preform Guass-Jordan elimination on the selected triangular matrix to determine it's inverse.
+// triangle reports whether the matrix is upper and lower triangular in a single pass over its off diagonal elements.
+func (m MatrixStruct) triangle() (upper, lower bool) {
+	upper, lower = true, true
+	// Stop as soon as the matrix is neither.
+	for i := 0; i < m.Rows && (upper || lower); i++ {
+		for j := 0; j < m.Columns; j++ {
+			if j == i || m.Elements[i*m.Columns+j] == 0 {
+				continue
+			}
+			if j < i {
+				upper = false
+			} else {
+				lower = false
+			}
+		}
+	}
+	return upper, lower
+}
+
+// TriangleInverse will return the inverse of the selected triangular matrix using the packed triangular kernels.
 func (m MatrixStruct) TriangleInverse() (*MatrixStruct, error) {
 	if m.Columns != m.Rows {
 		return nil, errors.New("Not a square matrix")
 	}
 
-	inverse, _ := Eye(m.Columns, m.Rows)
+	upper, lower := m.triangle()
+	if upper {
+		inverse, err := m.packUpper().Inverse()
+		if err != nil {
+			return nil, err
+		}
+		return inverse.Dense(), nil
+	}
+	if lower {
+		inverse, err := m.packLower().Inverse()
+		if err != nil {
+			return nil, err
+		}
+		return inverse.Dense(), nil
+	}
 
-	if m.IsUpperTriangular() {
-		for k := m.Rows - 1; k >= 0; k-- {
-			t, _ := m.GetValue(k, k)
-			inverse.SetValue(k, k, 1/t)
-			for i := k - 1; i >= 0; i-- {
-				a, _ := m.Minor(i, i, i, k)
-				b, _ := inverse.Minor(i, k, k, k)
-				c, _ := a.Multiply(b)
-				d, _ := m.GetValue(i, i)
-				e, _ := c.ScalarMultiply(-1/d).GetValue(0, 0)
-				inverse.SetValue(i, k, e)
-			}
+	return nil, errors.New("Not a triangular matrix")
+}
+
+// TriangleSolve will solve the system m*x = b using forward or back substitution, where m is a triangular matrix.
+func (m MatrixStruct) TriangleSolve(b *MatrixStruct) (*MatrixStruct, error) {
+	if m.Columns != m.Rows {
+		return nil, errors.New("Not a square matrix")
+	}
+
+	if b.Rows != m.Rows {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	x := b.Clone()
+
+	upper, lower := m.triangle()
+	if upper {
+		if err := backSubstitution(&m, x, false); err != nil {
+			return nil, err
 		}
-		return inverse, nil
+		return x, nil
 	}
-	if m.IsLowerTriangular() {
-		for k := 0; k < m.Rows; k++ {
-			t, _ := m.GetValue(k, k)
-			inverse.SetValue(k, k, 1/t)
-			for i := k + 1; i < m.Rows; i++ {
-				a, _ := m.Minor(i, i, k, i-1)
-				b, _ := inverse.Minor(k, i-1, k, k)
-				c, _ := a.Multiply(b)
-				d, _ := m.GetValue(i, i)
-				e, _ := c.ScalarMultiply(-1/d).GetValue(0, 0)
-				inverse.SetValue(i, k, e)
-			}
+	if lower {
+		if err := forwardSubstitution(&m, x, false); err != nil {
+			return nil, err
 		}
-		return inverse, nil
+		return x, nil
 	}
 
 	return nil, errors.New("Not a triangular matrix")
 }
+
+// forwardSubstitution overwrites x with the solution of l*x = x, taking the diagonal of l as one when unit is true.
+func forwardSubstitution(l, x *MatrixStruct, unit bool) error {
+	n := x.Rows
+	if n > l.Rows || n > l.Columns {
+		return errors.New("matrix dimensions do not agree")
+	}
+	for i := 0; i < n; i++ {
+		d := l.Elements[i*l.Columns+i]
+		if !unit && d == 0 {
+			return errors.New("Matrix is singular")
+		}
+		for c := 0; c < x.Columns; c++ {
+			sum := x.Elements[i*x.Columns+c]
+			for k := 0; k < i; k++ {
+				sum -= l.Elements[i*l.Columns+k] * x.Elements[k*x.Columns+c]
+			}
+			if !unit {
+				sum /= d
+			}
+			x.Elements[i*x.Columns+c] = sum
+		}
+	}
+	return nil
+}
+
+// backSubstitution overwrites x with the solution of u*x = x, taking the diagonal of u as one when unit is true.
+func backSubstitution(u, x *MatrixStruct, unit bool) error {
+	n := x.Rows
+	if n > u.Rows || n > u.Columns {
+		return errors.New("matrix dimensions do not agree")
+	}
+	for i := n - 1; i >= 0; i-- {
+		d := u.Elements[i*u.Columns+i]
+		if !unit && d == 0 {
+			return errors.New("Matrix is singular")
+		}
+		for c := 0; c < x.Columns; c++ {
+			sum := x.Elements[i*x.Columns+c]
+			for k := i + 1; k < n; k++ {
+				sum -= u.Elements[i*u.Columns+k] * x.Elements[k*x.Columns+c]
+			}
+			if !unit {
+				sum /= d
+			}
+			x.Elements[i*x.Columns+c] = sum
+		}
+	}
+	return nil
+}
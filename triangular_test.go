@@ -112,3 +112,63 @@ func Benchmark_TriangleInverse_Lower(b *testing.B) {
 		_, _ = a.TriangleInverse()
 	}
 }
+
+func TestTriangleSolve(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := Matrix(3, 3, []float64{2, 1, 1, 0, 4, 2, 0, 0, 5})
+	assert.Nil(err)
+	b, err := Vector(7, 14, 15)
+	assert.Nil(err)
+
+	x, err := a.TriangleSolve(b)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1, 2, 3}, x.Elements, 1e-12)
+
+	c, err := Matrix(3, 3, []float64{2, 0, 0, 1, 4, 0, 1, 2, 5})
+	assert.Nil(err)
+	d, err := Vector(2, 9, 20)
+	assert.Nil(err)
+
+	y, err := c.TriangleSolve(d)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{1, 2, 3}, y.Elements, 1e-12)
+
+	e, err := Matrix(2, 2, []float64{1, 2, 0, 0})
+	assert.Nil(err)
+	f, err := Vector(1, 1)
+	assert.Nil(err)
+	z, err := e.TriangleSolve(f)
+	assert.Nil(z)
+	assert.NotNil(err)
+
+	g, err := Matrix(2, 2, []float64{1, 2, 3, 4})
+	assert.Nil(err)
+	z, err = g.TriangleSolve(f)
+	assert.Nil(z)
+	assert.NotNil(err)
+}
+
+// TestSubstitutionLeadingBlock checks that the substitutions solve with the leading square block of a tall triangular factor, as the least-squares solver does with the tall R of a QR decomposition. They used to take the size from the factor and read past the end of x.
+func TestSubstitutionLeadingBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	R, err := Matrix(3, 2, []float64{2, 1, 0, 4, 0, 0})
+	assert.Nil(err)
+	x, err := Vector(4, 8)
+	assert.Nil(err)
+	assert.Nil(backSubstitution(R, x, false))
+	assert.InDeltaSlice([]float64{1, 2}, x.Elements, 1e-12)
+
+	L := R.Transpose()
+	y, err := Vector(2, 9)
+	assert.Nil(err)
+	assert.Nil(forwardSubstitution(L, y, false))
+	assert.InDeltaSlice([]float64{1, 2}, y.Elements, 1e-12)
+
+	// A right hand side with more rows than the factor has rows or columns is rejected.
+	z, err := Vector(1, 2, 3)
+	assert.Nil(err)
+	assert.EqualError(backSubstitution(R, z, false), "matrix dimensions do not agree")
+	assert.EqualError(forwardSubstitution(L, z, false), "matrix dimensions do not agree")
+}
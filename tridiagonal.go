@@ -0,0 +1,202 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Tridiagonal is a square matrix whose only nonzero elements are on the diagonal and the diagonals directly above and below it.
+type Tridiagonal struct {
+	// Lower[i] is the element in row i+1 and column i, and Upper[i] is the element in row i and column i+1.
+	Lower, Diagonal, Upper []float64
+}
+
+// NewTridiagonal will return a tridiagonal matrix that uses the given diagonals directly, checking that the off diagonals are one element shorter.
+func NewTridiagonal(lower, diagonal, upper []float64) (*Tridiagonal, error) {
+	n := len(diagonal)
+	if n == 0 {
+		return nil, errors.New("Incorrect matrix dimensions")
+	}
+	if len(lower) != n-1 || len(upper) != n-1 {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+	return &Tridiagonal{Lower: lower, Diagonal: diagonal, Upper: upper}, nil
+}
+
+// Tridiagonal will return the three diagonals of a square matrix, or an error if it has nonzero elements anywhere else.
+func (m MatrixStruct) Tridiagonal() (*Tridiagonal, error) {
+	if !m.IsSquare() {
+		return nil, errors.New("Not a square matrix")
+	}
+	if lower, upper := m.Bandwidth(); lower > 1 || upper > 1 {
+		return nil, errors.New("Matrix is not tridiagonal")
+	}
+
+	n := m.Rows
+	t := &Tridiagonal{Diagonal: make([]float64, n)}
+	if n > 0 {
+		t.Lower = make([]float64, n-1)
+		t.Upper = make([]float64, n-1)
+	}
+	for i := 0; i < n; i++ {
+		t.Diagonal[i] = m.Elements[i*n+i]
+		if i < n-1 {
+			t.Upper[i] = m.Elements[i*n+i+1]
+			t.Lower[i] = m.Elements[(i+1)*n+i]
+		}
+	}
+	return t, nil
+}
+
+// Dims will return the number of rows and columns.
+func (t *Tridiagonal) Dims() (rows, columns int) {
+	return len(t.Diagonal), len(t.Diagonal)
+}
+
+// GetValue will return the element in row y and column x.
+func (t *Tridiagonal) GetValue(y, x int) (float64, error) {
+	n := len(t.Diagonal)
+	if y < 0 || y >= n || x < 0 || x >= n {
+		return 0, fmt.Errorf("The indexes must be in the bounds of the matrix.\nMatrix is %dx%d, indexes are %d,%d", n, n, y, x)
+	}
+
+	switch x - y {
+	case -1:
+		return t.Lower[x], nil
+	case 0:
+		return t.Diagonal[y], nil
+	case 1:
+		return t.Upper[y], nil
+	}
+	return 0, nil
+}
+
+// SetValue will set the element in row y and column x, which must be on one of the three diagonals.
+func (t *Tridiagonal) SetValue(y, x int, value float64) error {
+	n := len(t.Diagonal)
+	if y < 0 || y >= n || x < 0 || x >= n {
+		return fmt.Errorf("The indexes must be in the bounds of the matrix.\nMatrix is %dx%d, indexes are %d,%d", n, n, y, x)
+	}
+
+	switch x - y {
+	case -1:
+		t.Lower[x] = value
+	case 0:
+		t.Diagonal[y] = value
+	case 1:
+		t.Upper[y] = value
+	default:
+		return errors.New("Element is outside the band of the matrix")
+	}
+	return nil
+}
+
+// MulVec will write the product of the matrix and x into dst.
+func (t *Tridiagonal) MulVec(dst, x []float64) {
+	n := len(t.Diagonal)
+	for i := 0; i < n; i++ {
+		sum := t.Diagonal[i] * x[i]
+		if i > 0 {
+			sum += t.Lower[i-1] * x[i-1]
+		}
+		if i < n-1 {
+			sum += t.Upper[i] * x[i+1]
+		}
+		dst[i] = sum
+	}
+}
+
+// T will return the transpose of the matrix, which satisfies Interface.
+func (t *Tridiagonal) T() Interface {
+	return t.Transpose()
+}
+
+// Transpose will return the transpose of the matrix, which swaps the sub and super diagonals.
+func (t *Tridiagonal) Transpose() *Tridiagonal {
+	return &Tridiagonal{
+		Lower:    append([]float64(nil), t.Upper...),
+		Diagonal: append([]float64(nil), t.Diagonal...),
+		Upper:    append([]float64(nil), t.Lower...),
+	}
+}
+
+// Dense will return the matrix as a MatrixStruct.
+func (t *Tridiagonal) Dense() *MatrixStruct {
+	n := len(t.Diagonal)
+	m, _ := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		m.Elements[i*n+i] = t.Diagonal[i]
+		if i < n-1 {
+			m.Elements[i*n+i+1] = t.Upper[i]
+			m.Elements[(i+1)*n+i] = t.Lower[i]
+		}
+	}
+	return m
+}
+
+// CSR will return the nonzero elements of the matrix in compressed sparse row format.
+func (t *Tridiagonal) CSR() *CSR {
+	n := len(t.Diagonal)
+	a := &CSR{Rows: n, Columns: n, RowPointers: make([]int, n+1)}
+	for i := 0; i < n; i++ {
+		for j := i - 1; j <= i+1; j++ {
+			if j < 0 || j >= n {
+				continue
+			}
+			if value, _ := t.GetValue(i, j); value != 0 {
+				a.ColumnIndices = append(a.ColumnIndices, j)
+				a.Values = append(a.Values, value)
+			}
+		}
+		a.RowPointers[i+1] = len(a.Values)
+	}
+	return a
+}
+
+// Banded will return the matrix in banded storage with one diagonal either side.
+func (t *Tridiagonal) Banded() *Banded {
+	n := len(t.Diagonal)
+	b, _ := NewBanded(n, n, 1, 1)
+	for i := 0; i < n; i++ {
+		b.Values[i*3+1] = t.Diagonal[i]
+		if i < n-1 {
+			b.Values[i*3+2] = t.Upper[i]
+			b.Values[(i+1)*3] = t.Lower[i]
+		}
+	}
+	return b
+}
+
+// Solve will return the solution of T*x = b with the Thomas algorithm, which does not pivot and suits diagonally dominant or positive definite matrices.
+func (t *Tridiagonal) Solve(b []float64) ([]float64, error) {
+	n := len(t.Diagonal)
+	if len(b) != n {
+		return nil, errors.New("matrix dimensions do not agree")
+	}
+
+	// Without pivoting this is only stable for matrices like those from splines and diffusion problems, others should use the banded LU decomposition.
+	// The forward sweep eliminates the sub diagonal, keeping the modified super diagonal in c and right hand side in x.
+	c := make([]float64, n)
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		pivot := t.Diagonal[i]
+		x[i] = b[i]
+		if i > 0 {
+			pivot -= t.Lower[i-1] * c[i-1]
+			x[i] -= t.Lower[i-1] * x[i-1]
+		}
+		if pivot == 0 || math.IsNaN(pivot) {
+			return nil, errors.New("Matrix is singular")
+		}
+		if i < n-1 {
+			c[i] = t.Upper[i] / pivot
+		}
+		x[i] /= pivot
+	}
+
+	for i := n - 2; i >= 0; i-- {
+		x[i] -= c[i] * x[i+1]
+	}
+	return x, nil
+}
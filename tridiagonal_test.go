@@ -0,0 +1,92 @@
+package matrix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// spline returns the tridiagonal system for the second derivatives of a natural cubic spline through n equally spaced points.
+func spline(n int) *Tridiagonal {
+	lower := make([]float64, n-1)
+	diagonal := make([]float64, n)
+	upper := make([]float64, n-1)
+	for i := range diagonal {
+		diagonal[i] = 4
+	}
+	for i := range lower {
+		lower[i] = 1
+		upper[i] = 1
+	}
+	t, _ := NewTridiagonal(lower, diagonal, upper)
+	return t
+}
+
+func TestTridiagonal(t *testing.T) {
+	assert := assert.New(t)
+
+	T, err := NewTridiagonal([]float64{1, 2, 3}, []float64{4, 5, 6, 7}, []float64{-1, -2, -3})
+	assert.Nil(err)
+	dense := T.Dense()
+	assert.Equal([]float64{
+		4, -1, 0, 0,
+		1, 5, -2, 0,
+		0, 2, 6, -3,
+		0, 0, 3, 7,
+	}, dense.Elements)
+
+	value, _ := T.GetValue(2, 1)
+	assert.Equal(2.0, value)
+	value, _ = T.GetValue(0, 3)
+	assert.Equal(0.0, value)
+	assert.Nil(T.SetValue(1, 2, 9))
+	assert.Equal(9.0, T.Upper[1])
+	assert.EqualError(T.SetValue(0, 2, 1), "Element is outside the band of the matrix")
+
+	assert.Equal(T.Dense().Transpose().Elements, T.Transpose().Dense().Elements)
+	assert.Equal(T.Dense().Elements, T.Banded().Dense().Elements)
+
+	x := []float64{1, 2, 3, 4}
+	dst := make([]float64, 4)
+	T.MulVec(dst, x)
+	expected, _ := T.Dense().Multiply(&MatrixStruct{Rows: 4, Columns: 1, Capacity: 4, Elements: x})
+	assert.InDeltaSlice(expected.Elements, dst, 1e-12)
+
+	back, err := T.Dense().Tridiagonal()
+	assert.Nil(err)
+	assert.Equal(T, back)
+
+	_, err = NewTridiagonal([]float64{1}, []float64{1, 2, 3}, []float64{1, 2})
+	assert.EqualError(err, "matrix dimensions do not agree")
+	_, err = NewTridiagonal(nil, nil, nil)
+	assert.EqualError(err, "Incorrect matrix dimensions")
+	full, _ := Ones(3, 3)
+	_, err = full.Tridiagonal()
+	assert.EqualError(err, "Matrix is not tridiagonal")
+}
+
+func TestTridiagonalSolve(t *testing.T) {
+	assert := assert.New(t)
+
+	T := spline(50)
+	b := sequence(50)
+	x, err := T.Solve(b)
+	assert.Nil(err)
+	residual := make([]float64, 50)
+	T.MulVec(residual, x)
+	assert.InDeltaSlice(b, residual, 1e-12)
+
+	singular, _ := NewTridiagonal([]float64{1}, []float64{1, 1}, []float64{1})
+	_, err = singular.Solve([]float64{1, 2})
+	assert.EqualError(err, "Matrix is singular")
+	_, err = T.Solve(b[:3])
+	assert.EqualError(err, "matrix dimensions do not agree")
+}
+
+func BenchmarkTridiagonalSolve(b *testing.B) {
+	T := spline(10000)
+	rhs := sequence(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		T.Solve(rhs)
+	}
+}
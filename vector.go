@@ -18,7 +18,7 @@ func (m MatrixStruct) IsVector() bool {
 // Unit return a unit vector if the given matrix is actually a vector.
 func (m MatrixStruct) Unit() (*MatrixStruct, error) {
 	if m.IsVector() {
-		return m.ScalarMultiply(1 / m.Normal("2")), nil
+		return m.ScalarMultiply(1 / m.frobenius()), nil
 	}
 	return nil, errors.New("Matrix is not a Vector")
 }